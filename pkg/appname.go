@@ -0,0 +1,44 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// defaultApplicationName returns the name connections are tagged with when the config sets none:
+// the base name of the executable, which is what DBAs usually know a service by.
+func defaultApplicationName() string {
+	if len(os.Args) == 0 || os.Args[0] == "" {
+		return ""
+	}
+	return filepath.Base(os.Args[0])
+}
+
+// mysqlProgramNameAttribute is the connection attribute MySQL clients conventionally report their name in,
+// shown in performance_schema.session_connect_attrs.
+const mysqlProgramNameAttribute = "program_name"
+
+// applyMySQLProgramName adds the program_name connection attribute to cfg unless it sets one already.
+func applyMySQLProgramName(cfg *mysql.Config) {
+	name := defaultApplicationName()
+	if name == "" {
+		return
+	}
+
+	for _, attr := range strings.Split(cfg.ConnectionAttributes, ",") {
+		if key, _, _ := strings.Cut(attr, ":"); strings.TrimSpace(key) == mysqlProgramNameAttribute {
+			return
+		}
+	}
+
+	// Commas and colons separate the attributes, so they cannot be part of the value.
+	attr := mysqlProgramNameAttribute + ":" + strings.NewReplacer(",", "_", ":", "_").Replace(name)
+	if cfg.ConnectionAttributes == "" {
+		cfg.ConnectionAttributes = attr
+	} else {
+		cfg.ConnectionAttributes += "," + attr
+	}
+}
@@ -0,0 +1,50 @@
+package pkg
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultApplicationName(t *testing.T) {
+	assert.Equal(t, filepath.Base(os.Args[0]), defaultApplicationName())
+}
+
+func TestPostgresConfigDefaultApplicationName(t *testing.T) {
+	parsed, err := url.Parse(PostgresConfig{Host: "db.local", DBName: "orders"}.BuildDSN())
+	require.NoError(t, err)
+	assert.Equal(t, defaultApplicationName(), parsed.Query().Get("application_name"))
+
+	parsed, err = url.Parse(PostgresConfig{Host: "db.local", Params: map[string]string{"application_name": "reports"}}.BuildDSN())
+	require.NoError(t, err)
+	assert.Equal(t, "reports", parsed.Query().Get("application_name"), "Expected Params to take precedence")
+}
+
+func TestMongoConfigDefaultAppName(t *testing.T) {
+	clientOpts, err := MongoConfig{Hosts: []string{"localhost:27017"}}.ClientOptions()
+	require.NoError(t, err)
+	assert.Equal(t, defaultApplicationName(), *clientOpts.AppName)
+
+	clientOpts, err = MongoConfig{Hosts: []string{"localhost:27017"}, AppName: "orders-api"}.ClientOptions()
+	require.NoError(t, err)
+	assert.Equal(t, "orders-api", *clientOpts.AppName)
+}
+
+func TestApplyMySQLProgramName(t *testing.T) {
+	cfg := mysql.NewConfig()
+	applyMySQLProgramName(cfg)
+	assert.Equal(t, "program_name:"+defaultApplicationName(), cfg.ConnectionAttributes)
+
+	cfg.ConnectionAttributes = "team:payments"
+	applyMySQLProgramName(cfg)
+	assert.Equal(t, "team:payments,program_name:"+defaultApplicationName(), cfg.ConnectionAttributes)
+
+	cfg.ConnectionAttributes = "program_name:orders-api"
+	applyMySQLProgramName(cfg)
+	assert.Equal(t, "program_name:orders-api", cfg.ConnectionAttributes, "Expected an explicit program_name to be kept")
+}
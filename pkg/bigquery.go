@@ -0,0 +1,66 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/iterator"
+)
+
+// NewBigQueryConnection creates a BigQuery client for projectID and validates access by listing one dataset.
+// projectID may be bigquery.DetectProjectID to take it from the credentials. Credentials are taken from the
+// environment unless set with WithGoogleClientOptions, e.g. option.WithCredentialsFile; pass WithBigQueryLocation
+// to run jobs in a specific location, and WithEmulator to use a local BigQuery emulator serving HTTP.
+// If any error occurs, it logs the error and terminates the application.
+func NewBigQueryConnection(ctx context.Context, projectID string, opts ...Option) *bigquery.Client {
+	client, err := NewBigQueryConnectionE(ctx, projectID, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to BigQuery: %v", err.Error())
+	}
+
+	return client
+}
+
+// NewBigQueryConnectionE creates a BigQuery client like NewBigQueryConnection,
+// but returns an error instead of terminating the application.
+func NewBigQueryConnectionE(ctx context.Context, projectID string, opts ...Option) (_ *bigquery.Client, err error) {
+	o := newOptions(opts)
+
+	ctx, cancel := o.connectContext(ctx)
+	defer cancel()
+
+	ctx, span := o.startConnectSpan(ctx, "bigquery", projectID)
+	defer func() {
+		endSpan(span, err)
+		o.finishConnect("bigquery", projectID, err)
+	}()
+
+	o.Logger.Infof("Connecting to BigQuery in project %s", projectID)
+
+	client, err := bigquery.NewClient(ctx, projectID, o.google.httpOptions()...)
+	if err != nil {
+		return nil, connectionError("bigquery", StageOpen, fmt.Errorf("failed to create BigQuery client: %w", err))
+	}
+	client.Location = o.google.location
+
+	err = o.ping(ctx, func(ctx context.Context) error {
+		datasets := client.Datasets(ctx)
+		datasets.PageInfo().MaxSize = 1
+
+		_, err := datasets.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		client.Close()
+		return nil, connectionError("bigquery", StagePing, fmt.Errorf("failed to list BigQuery datasets: %w", err))
+	}
+
+	o.Logger.Infof("Successfully connected to BigQuery")
+	o.track(client)
+
+	return client, nil
+}
@@ -0,0 +1,30 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBigQueryConnection(t *testing.T) {
+	client, err := NewBigQueryConnectionE(context.Background(), "test-project", WithEmulator("localhost:9050"),
+		WithBigQueryLocation("EU"), WithConnectTimeout(2*time.Second))
+	if err != nil {
+		t.Skipf("BigQuery emulator is not reachable: %v", err)
+	}
+	defer client.Close()
+
+	assert.Equal(t, "EU", client.Location)
+}
+
+func TestNewBigQueryConnectionEUnreachableEmulator(t *testing.T) {
+	client, err := NewBigQueryConnectionE(context.Background(), "test-project",
+		WithEmulator("127.0.0.1:1"), WithConnectTimeout(200*time.Millisecond))
+
+	assert.Nil(t, client)
+	var connErr *ConnectionError
+	assert.ErrorAs(t, err, &connErr)
+	assert.Equal(t, StagePing, connErr.Stage)
+}
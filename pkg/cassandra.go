@@ -0,0 +1,195 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// defaultCassandraTimeout is applied to both the connect and the query timeout of new clusters.
+const defaultCassandraTimeout = time.Second
+
+// CassandraOption is the former type of the options in this file. They are Option values now, which the
+// Cassandra and ScyllaDB connectors accept together with the shared settings such as WithRetry or WithLogger.
+//
+// Deprecated: Use Option.
+type CassandraOption = Option
+
+// cassandraSettings holds the changes the Cassandra options make to the gocql cluster config, in order.
+type cassandraSettings []func(*gocql.ClusterConfig)
+
+// apply makes the changes to cluster.
+func (s cassandraSettings) apply(cluster *gocql.ClusterConfig) {
+	for _, fn := range s {
+		fn(cluster)
+	}
+}
+
+// withCluster returns an Option that changes the gocql cluster config with fn.
+func withCluster(fn func(*gocql.ClusterConfig)) Option {
+	return func(o *Options) {
+		o.cassandra = append(o.cassandra, fn)
+	}
+}
+
+// WithCassandraTimeout sets the connect and query timeout. Defaults to one second.
+func WithCassandraTimeout(d time.Duration) Option {
+	return withCluster(func(c *gocql.ClusterConfig) {
+		c.Timeout = d
+		c.ConnectTimeout = d
+	})
+}
+
+// WithConsistency sets the default consistency level of the session. Defaults to gocql.Quorum.
+func WithConsistency(consistency gocql.Consistency) Option {
+	return withCluster(func(c *gocql.ClusterConfig) {
+		c.Consistency = consistency
+	})
+}
+
+// WithKeyspace selects the keyspace the session is bound to.
+func WithKeyspace(keyspace string) Option {
+	return withCluster(func(c *gocql.ClusterConfig) {
+		c.Keyspace = keyspace
+	})
+}
+
+// WithCassandraAuth authenticates with the username and password through the PasswordAuthenticator.
+func WithCassandraAuth(username, password string) Option {
+	return withCluster(func(c *gocql.ClusterConfig) {
+		c.Authenticator = gocql.PasswordAuthenticator{Username: username, Password: password}
+	})
+}
+
+// WithDCAwareRouting routes queries to replicas in localDC, falling back to other datacenters only
+// when no local host is available, and prefers the replica owning the partition.
+func WithDCAwareRouting(localDC string) Option {
+	return withCluster(func(c *gocql.ClusterConfig) {
+		c.PoolConfig.HostSelectionPolicy = gocql.TokenAwareHostPolicy(gocql.DCAwareRoundRobinPolicy(localDC))
+	})
+}
+
+// WithCassandraRetryPolicy sets the policy deciding whether failed queries are retried,
+// e.g. &gocql.ExponentialBackoffRetryPolicy{NumRetries: 3}.
+func WithCassandraRetryPolicy(policy gocql.RetryPolicy) Option {
+	return withCluster(func(c *gocql.ClusterConfig) {
+		c.RetryPolicy = policy
+	})
+}
+
+// WithClusterConfig calls fn with the cluster config after the defaults and the preceding options are applied,
+// for settings without a dedicated option such as SslOpts, ReconnectionPolicy or a custom Authenticator.
+func WithClusterConfig(fn func(*gocql.ClusterConfig)) Option {
+	return withCluster(fn)
+}
+
+// NewCassandraConnection establishes a session with a Cassandra cluster reachable through the given hosts.
+// The cluster uses a one second timeout and quorum consistency unless overridden by opts.
+// If successful, it returns the session to interact with the cluster.
+// If any error occurs, it logs the error and terminates the application.
+func NewCassandraConnection(hosts []string, opts ...Option) *gocql.Session {
+	session, err := NewCassandraConnectionE(hosts, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to Cassandra: %v", err.Error())
+	}
+
+	return session
+}
+
+// NewCassandraConnectionE establishes a session with a Cassandra cluster like NewCassandraConnection,
+// but returns an error instead of terminating the application.
+func NewCassandraConnectionE(hosts []string, opts ...Option) (*gocql.Session, error) {
+	o := newOptions(opts)
+	if len(hosts) == 0 {
+		return nil, o.failed(connectionError("cassandra", StageConfig, errors.New("no Cassandra hosts provided")))
+	}
+
+	cluster := gocql.NewCluster(hosts...)
+	cluster.Timeout = defaultCassandraTimeout
+	cluster.ConnectTimeout = defaultCassandraTimeout
+	cluster.Consistency = gocql.Quorum
+
+	return createCassandraSession(context.Background(), "Cassandra", "cassandra", cluster, o)
+}
+
+// NewCassandraConnectionFromConfig establishes a session with a Cassandra cluster described by a
+// caller-built cluster config, such as one from gocql.NewCluster, after applying opts to it.
+// No defaults are applied, so the gocql defaults hold for anything the config leaves unset.
+// If any error occurs, it logs the error and terminates the application.
+func NewCassandraConnectionFromConfig(cluster *gocql.ClusterConfig, opts ...Option) *gocql.Session {
+	session, err := NewCassandraConnectionFromConfigE(cluster, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to Cassandra: %v", err.Error())
+	}
+
+	return session
+}
+
+// NewCassandraConnectionFromConfigE establishes a session like NewCassandraConnectionFromConfig,
+// but returns an error instead of terminating the application.
+func NewCassandraConnectionFromConfigE(cluster *gocql.ClusterConfig, opts ...Option) (*gocql.Session, error) {
+	o := newOptions(opts)
+	if cluster == nil || len(cluster.Hosts) == 0 {
+		return nil, o.failed(connectionError("cassandra", StageConfig, errors.New("no Cassandra hosts provided")))
+	}
+
+	return createCassandraSession(context.Background(), "Cassandra", "cassandra", cluster, o)
+}
+
+// createCassandraSession applies the Cassandra options in o to cluster, creates the session and verifies it
+// by reading the release version of the node it is connected to, retrying both according to the retry policy
+// in o. A deadline from ctx, WithContext or WithConnectTimeout also caps the cluster's ConnectTimeout,
+// since gocql creates sessions without a context. The session is closed if the check fails.
+// Errors are ConnectionErrors for system.
+func createCassandraSession(ctx context.Context, label, system string, cluster *gocql.ClusterConfig, o *Options) (_ *gocql.Session, err error) {
+	o.cassandra.apply(cluster)
+
+	host := strings.Join(cluster.Hosts, ",")
+	ctx, cancel := o.connectContext(ctx)
+	defer cancel()
+
+	ctx, span := o.startConnectSpan(ctx, system, host)
+	defer func() {
+		auth, _ := cluster.Authenticator.(gocql.PasswordAuthenticator)
+		err = redactErr(err, "", auth.Password)
+		endSpan(span, err)
+		o.finishConnect(system, host, err)
+	}()
+
+	if deadline, ok := ctx.Deadline(); ok && (cluster.ConnectTimeout <= 0 || time.Until(deadline) < cluster.ConnectTimeout) {
+		cluster.ConnectTimeout = time.Until(deadline)
+	}
+
+	o.Logger.Infof("Trying to connect to %s", label)
+
+	var session *gocql.Session
+	var version string
+	stage := StageOpen
+	err = o.Retry.do(ctx, o.Logger, func(ctx context.Context) error {
+		created, err := cluster.CreateSession()
+		if err != nil {
+			stage = StageOpen
+			return fmt.Errorf("failed to create %s session: %w", label, err)
+		}
+		if !o.SkipPing {
+			if err := created.Query("SELECT release_version FROM system.local").WithContext(ctx).Scan(&version); err != nil {
+				created.Close()
+				stage = StagePing
+				return fmt.Errorf("failed to verify %s session: %w", label, err)
+			}
+		}
+		session = created
+		return nil
+	})
+	if err != nil {
+		return nil, connectionError(system, stage, err)
+	}
+
+	o.Logger.Infof("Successfully connected to %s %s", label, version)
+	o.track(session)
+	return session, nil
+}
@@ -0,0 +1,30 @@
+//go:build integration
+
+package pkg
+
+import (
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCassandraConnection(t *testing.T) {
+	session := NewCassandraConnection([]string{"localhost:9042"}, WithConsistency(gocql.One))
+	defer session.Close()
+
+	assert.NotNil(t, session)
+
+	err := session.Query("SELECT release_version FROM system.local").Exec()
+	assert.NoError(t, err, "Expected no error when executing query on Cassandra")
+}
+
+func TestNewCassandraConnectionFromConfig(t *testing.T) {
+	cluster := gocql.NewCluster("localhost:9042")
+	cluster.Consistency = gocql.One
+
+	session := NewCassandraConnectionFromConfig(cluster, WithCassandraRetryPolicy(&gocql.SimpleRetryPolicy{NumRetries: 2}))
+	defer session.Close()
+
+	assert.NotNil(t, session)
+}
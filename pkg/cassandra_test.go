@@ -0,0 +1,65 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCassandraConnectionENoHosts(t *testing.T) {
+	session, err := NewCassandraConnectionE(nil)
+
+	assert.Nil(t, session)
+	var connErr *ConnectionError
+	assert.ErrorAs(t, err, &connErr, "Expected an error when no hosts are provided")
+	assert.Equal(t, StageConfig, connErr.Stage)
+}
+
+func TestNewCassandraConnectionFromConfigENoHosts(t *testing.T) {
+	session, err := NewCassandraConnectionFromConfigE(nil)
+	assert.Nil(t, session)
+	assert.Error(t, err)
+
+	session, err = NewCassandraConnectionFromConfigE(gocql.NewCluster())
+	assert.Nil(t, session)
+	assert.Error(t, err)
+}
+
+func TestCassandraOptionsConfigureCluster(t *testing.T) {
+	cluster := gocql.NewCluster("localhost:9042")
+	retry := &gocql.ExponentialBackoffRetryPolicy{NumRetries: 3}
+
+	newOptions([]Option{
+		WithCassandraAuth("app", "s3cret"),
+		WithDCAwareRouting("eu-west"),
+		WithCassandraRetryPolicy(retry),
+		WithClusterConfig(func(c *gocql.ClusterConfig) {
+			c.ProtoVersion = 4
+		}),
+	}).cassandra.apply(cluster)
+
+	assert.Equal(t, gocql.PasswordAuthenticator{Username: "app", Password: "s3cret"}, cluster.Authenticator)
+	assert.NotNil(t, cluster.PoolConfig.HostSelectionPolicy)
+	assert.Same(t, retry, cluster.RetryPolicy)
+	assert.Equal(t, 4, cluster.ProtoVersion)
+}
+
+func TestCassandraConnectionHooks(t *testing.T) {
+	var stages []Stage
+	var hookErr error
+
+	session, err := NewCassandraConnectionE([]string{"127.0.0.1:1"}, WithCassandraAuth("app", "s3cret"),
+		WithConnectTimeout(time.Second), WithOnConnect(func(string, string) {
+			t.Error("Expected no OnConnect call for a failed connection")
+		}), WithOnError(func(stage Stage, err error) {
+			stages = append(stages, stage)
+			hookErr = err
+		}))
+
+	assert.Nil(t, session)
+	assert.ErrorIs(t, err, hookErr)
+	assert.Equal(t, []Stage{StageOpen}, stages)
+	assert.NotContains(t, hookErr.Error(), "s3cret")
+}
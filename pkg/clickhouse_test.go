@@ -0,0 +1,31 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClickHouseConnection(t *testing.T) {
+	db := NewClickHouseConnection("clickhouse://default:@localhost:9000/default")
+
+	assert.NotNil(t, db)
+
+	err := db.Ping()
+	assert.NoError(t, err, "Expected no error when pinging ClickHouse")
+}
+
+func TestNewClickHouseConnectionCtxInvalidDSN(t *testing.T) {
+	var stage Stage
+	db, err := NewClickHouseConnectionCtx(context.Background(), "clickhouse://localhost:9000/default?dial_timeout=soon",
+		WithOnError(func(s Stage, _ error) { stage = s }))
+
+	assert.Nil(t, db)
+	var connErr *ConnectionError
+	if assert.ErrorAs(t, err, &connErr) {
+		assert.Equal(t, "clickhouse", connErr.Driver)
+		assert.Equal(t, StageConfig, connErr.Stage)
+	}
+	assert.Equal(t, StageConfig, stage, "Expected OnError to be called")
+}
@@ -0,0 +1,110 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	defaultCockroachPort    = "26257"
+	defaultCockroachSSLMode = "verify-full"
+
+	// sqlStateSerializationFailure is the SQLSTATE CockroachDB returns for transactions that must be retried.
+	sqlStateSerializationFailure = "40001"
+)
+
+// defaultCockroachTxRetry is used by RunCockroachTx when the caller passes a zero RetryConfig.
+var defaultCockroachTxRetry = RetryConfig{Attempts: 5, InitialDelay: 50 * time.Millisecond, MaxDelay: 2 * time.Second}
+
+// NewCockroachConnection establishes a connection to a CockroachDB cluster through the pgx driver.
+// String DSNs without a port use 26257, and those without an sslmode use verify-full,
+// which matches the connection strings CockroachDB generates. A *pgx.ConnConfig is used unchanged.
+// If any error occurs, it logs the error and terminates the application.
+func NewCockroachConnection[T string | *pgx.ConnConfig](cfg T, opts ...Option) *sql.DB {
+	db, err := NewCockroachConnectionCtx(context.Background(), cfg, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to CockroachDB: %v", err.Error())
+	}
+
+	return db
+}
+
+// NewCockroachConnectionCtx establishes a connection to a CockroachDB cluster like NewCockroachConnection,
+// but bounds the ping with ctx and returns an error instead of terminating the application.
+func NewCockroachConnectionCtx[T string | *pgx.ConnConfig](ctx context.Context, cfg T, opts ...Option) (*sql.DB, error) {
+	switch v := any(cfg).(type) {
+	case string:
+		dsn, err := cockroachDSN(v)
+		if err != nil {
+			return nil, redactErr(err, v)
+		}
+		return NewPostgresPgxConnectionCtx(ctx, dsn, opts...)
+	case *pgx.ConnConfig:
+		return NewPostgresPgxConnectionCtx(ctx, v, opts...)
+	default:
+		return nil, fmt.Errorf("invalid config type: %T", v)
+	}
+}
+
+// cockroachDSN fills in the CockroachDB port and sslmode defaults on a URL or key/value DSN.
+func cockroachDSN(dsn string) (string, error) {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return "", fmt.Errorf("invalid CockroachDB connection string: %w", err)
+		}
+		if u.Port() == "" {
+			u.Host = net.JoinHostPort(u.Hostname(), defaultCockroachPort)
+		}
+		query := u.Query()
+		if query.Get("sslmode") == "" {
+			query.Set("sslmode", defaultCockroachSSLMode)
+			u.RawQuery = query.Encode()
+		}
+		return u.String(), nil
+	}
+
+	if !hasKeyValue(dsn, "port") {
+		dsn += " port=" + defaultCockroachPort
+	}
+	if !hasKeyValue(dsn, "sslmode") {
+		dsn += " sslmode=" + defaultCockroachSSLMode
+	}
+	return strings.TrimSpace(dsn), nil
+}
+
+// hasKeyValue reports whether the key/value DSN sets key.
+func hasKeyValue(dsn, key string) bool {
+	for _, field := range strings.Fields(dsn) {
+		if strings.HasPrefix(field, key+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSerializationFailure reports whether err carries SQLSTATE 40001, which CockroachDB and PostgreSQL
+// return for transactions that lost a conflict and can be retried from the start.
+func IsSerializationFailure(err error) bool {
+	var state interface{ SQLState() string }
+	return errors.As(err, &state) && state.SQLState() == sqlStateSerializationFailure
+}
+
+// RunCockroachTx runs fn inside a transaction on db and commits it, rolling back if fn or the commit fails.
+// Transactions that fail with a serialization error are retried from the start according to retry;
+// a zero RetryConfig uses five attempts with a short backoff. Other errors are returned immediately.
+func RunCockroachTx(ctx context.Context, db *sql.DB, retry RetryConfig, fn func(*sql.Tx) error) error {
+	if retry.Attempts == 0 {
+		retry = defaultCockroachTxRetry
+	}
+
+	return WithTx(ctx, db, fn, WithTxRetry(retry), WithTxRetryIf(IsSerializationFailure))
+}
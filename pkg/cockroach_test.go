@@ -0,0 +1,79 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCockroachConnection(t *testing.T) {
+	dsn := "postgres://root@localhost:26257/defaultdb?sslmode=disable"
+
+	db := NewCockroachConnection(dsn)
+
+	assert.NotNil(t, db)
+
+	err := db.Ping()
+	assert.NoError(t, err, "Expected no error when pinging CockroachDB")
+}
+
+func TestCockroachDSNDefaults(t *testing.T) {
+	tests := []struct {
+		name string
+		dsn  string
+		want string
+	}{
+		{"url without port or sslmode", "postgres://root@crdb.local/bank", "postgres://root@crdb.local:26257/bank?sslmode=verify-full"},
+		{"url keeps explicit values", "postgresql://root@crdb.local:5433/bank?sslmode=disable", "postgresql://root@crdb.local:5433/bank?sslmode=disable"},
+		{"key/value", "host=crdb.local user=root dbname=bank", "host=crdb.local user=root dbname=bank port=26257 sslmode=verify-full"},
+		{"key/value keeps explicit values", "host=crdb.local port=26000 sslmode=require", "host=crdb.local port=26000 sslmode=require"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cockroachDSN(tt.dsn)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRunCockroachTxRetriesSerializationFailures(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	calls := 0
+	err = RunCockroachTx(context.Background(), db, RetryConfig{Attempts: 3, InitialDelay: time.Millisecond}, func(*sql.Tx) error {
+		calls++
+		if calls < 3 {
+			return &pgconn.PgError{Code: "40001", Message: "restart transaction"}
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRunCockroachTxReturnsOtherErrors(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	calls := 0
+	boom := errors.New("boom")
+	err = RunCockroachTx(context.Background(), db, RetryConfig{}, func(*sql.Tx) error {
+		calls++
+		return boom
+	})
+
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,94 @@
+package pkg
+
+import (
+	"crypto/tls"
+	"net"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisConfig describes a single Redis server. It is accepted by NewRedisConnection, and Options converts it
+// into the equivalent *redis.Options.
+type RedisConfig struct {
+	// Addr is the server as host:port. Defaults to localhost:6379.
+	Addr     string `json:"addr" yaml:"addr"`
+	Username string `json:"username" yaml:"username"`
+	Password string `json:"password" yaml:"password"`
+	DB       int    `json:"db" yaml:"db"`
+	// TLS enables TLS with the system root certificates. It is implied by the other TLS fields, which managed
+	// services with in-transit encryption such as ElastiCache or Upstash need.
+	TLS bool `json:"tls" yaml:"tls"`
+	// CAFile adds the certificate authorities used to verify the server.
+	CAFile string `json:"ca_file" yaml:"ca_file"`
+	// CertFile and KeyFile provide a client certificate for mutual TLS.
+	CertFile string `json:"cert_file" yaml:"cert_file"`
+	KeyFile  string `json:"key_file" yaml:"key_file"`
+	// ServerName is the name the server certificate must be valid for. Defaults to the host of Addr.
+	ServerName string `json:"server_name" yaml:"server_name"`
+	// InsecureSkipVerify accepts any server certificate. Use it only against test servers.
+	InsecureSkipVerify bool `json:"insecure_skip_verify" yaml:"insecure_skip_verify"`
+	// PoolSize is the maximum number of connections. Zero keeps the go-redis default.
+	PoolSize int `json:"pool_size" yaml:"pool_size"`
+}
+
+// Options returns the go-redis client options for the config. It fails if the TLS files cannot be loaded.
+func (c RedisConfig) Options() (*redis.Options, error) {
+	opts := &redis.Options{
+		Addr:     c.Addr,
+		Username: c.Username,
+		Password: c.Password,
+		DB:       c.DB,
+		PoolSize: c.PoolSize,
+	}
+
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	opts.TLSConfig = tlsConfig
+
+	return opts, nil
+}
+
+// tlsConfig builds the TLS configuration of c, or returns nil if c does not use TLS.
+func (c RedisConfig) tlsConfig() (*tls.Config, error) {
+	if !c.TLS && c.CAFile == "" && c.CertFile == "" && c.KeyFile == "" && c.ServerName == "" && !c.InsecureSkipVerify {
+		return nil, nil
+	}
+
+	cfg, err := LoadTLSConfig(c.CAFile, c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.ServerName = c.ServerName
+	if cfg.ServerName == "" {
+		host, _, err := net.SplitHostPort(c.Addr)
+		if err != nil {
+			host = c.Addr
+		}
+		cfg.ServerName = host
+	}
+	cfg.InsecureSkipVerify = c.InsecureSkipVerify
+
+	return cfg, nil
+}
+
+// DatabasesConfig groups the configs of the databases a service uses, so that they can be unmarshaled
+// from a single section of a JSON or YAML config file. Databases that are not configured stay nil.
+//
+// Durations such as SQLiteConfig.BusyTimeout are written as strings like "5s" in YAML, and as
+// integer nanoseconds in JSON.
+type DatabasesConfig struct {
+	Postgres      *PostgresConfig      `json:"postgres,omitempty" yaml:"postgres,omitempty"`
+	Mongo         *MongoConfig         `json:"mongo,omitempty" yaml:"mongo,omitempty"`
+	Redis         *RedisConfig         `json:"redis,omitempty" yaml:"redis,omitempty"`
+	MSSQL         *MSSQLConfig         `json:"mssql,omitempty" yaml:"mssql,omitempty"`
+	SQLite        *SQLiteConfig        `json:"sqlite,omitempty" yaml:"sqlite,omitempty"`
+	Elasticsearch *ElasticsearchConfig `json:"elasticsearch,omitempty" yaml:"elasticsearch,omitempty"`
+	DynamoDB      *DynamoDBConfig      `json:"dynamodb,omitempty" yaml:"dynamodb,omitempty"`
+	// Pool holds the pool settings to be passed to NewSQLDBConnectionWithPool.
+	Pool *PoolConfig `json:"pool,omitempty" yaml:"pool,omitempty"`
+	// Retry holds the retry policy for the startup pings, to be passed with WithRetry.
+	Retry *RetryConfig `json:"retry,omitempty" yaml:"retry,omitempty"`
+}
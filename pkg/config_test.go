@@ -0,0 +1,165 @@
+package pkg
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDatabasesConfigFromYAML(t *testing.T) {
+	data := `
+postgres:
+  host: db.local
+  port: 5432
+  user: app
+  password: s3cret
+  db_name: orders
+  ssl_mode: require
+redis:
+  addr: cache.local:6380
+  db: 2
+  tls: true
+sqlite:
+  path: /var/lib/app/app.db
+  journal_mode: WAL
+  busy_timeout: 5s
+retry:
+  attempts: 5
+  initial_delay: 250ms
+`
+
+	var cfg DatabasesConfig
+	assert.NoError(t, yaml.Unmarshal([]byte(data), &cfg))
+
+	assert.Equal(t, PostgresConfig{Host: "db.local", Port: 5432, User: "app", Password: "s3cret", DBName: "orders", SSLMode: "require"}, *cfg.Postgres)
+	assert.Equal(t, "/var/lib/app/app.db", cfg.SQLite.Path)
+	assert.Equal(t, 5*time.Second, cfg.SQLite.BusyTimeout)
+	assert.Equal(t, RetryConfig{Attempts: 5, InitialDelay: 250 * time.Millisecond}, *cfg.Retry)
+	assert.Nil(t, cfg.Mongo)
+
+	opts, err := cfg.Redis.Options()
+	assert.NoError(t, err)
+	assert.Equal(t, "cache.local:6380", opts.Addr)
+	assert.Equal(t, 2, opts.DB)
+	assert.Equal(t, "cache.local", opts.TLSConfig.ServerName)
+}
+
+func TestDatabasesConfigFromJSON(t *testing.T) {
+	data := `{
+		"mongo": {"hosts": ["mongo-1:27017", "mongo-2:27017"], "username": "app", "replica_set": "rs0", "tls": true},
+		"elasticsearch": {"addresses": ["https://es.local:9200"], "api_key": "a2V5"},
+		"dynamodb": {"region": "eu-west-1", "endpoint": "http://localhost:8000"}
+	}`
+
+	var cfg DatabasesConfig
+	assert.NoError(t, json.Unmarshal([]byte(data), &cfg))
+
+	assert.Equal(t, []string{"mongo-1:27017", "mongo-2:27017"}, cfg.Mongo.Hosts)
+	assert.Equal(t, "rs0", cfg.Mongo.ReplicaSet)
+	assert.True(t, cfg.Mongo.TLS)
+	assert.Equal(t, "a2V5", cfg.Elasticsearch.APIKey)
+	assert.Equal(t, DynamoDBConfig{Region: "eu-west-1", Endpoint: "http://localhost:8000"}, *cfg.DynamoDB)
+	assert.Nil(t, cfg.Postgres)
+}
+
+func TestRedisConfigOptionsWithoutTLS(t *testing.T) {
+	opts, err := RedisConfig{Addr: "localhost:6379", Password: "secret", PoolSize: 20}.Options()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", opts.Password)
+	assert.Equal(t, 20, opts.PoolSize)
+	assert.Nil(t, opts.TLSConfig)
+}
+
+// newTLSRedisServer serves just enough of the Redis protocol over TLS for a client to connect and ping:
+// PING is answered with PONG and every other command with an error.
+func newTLSRedisServer(t *testing.T, certFile, keyFile string) string {
+	t.Helper()
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	assert.NoError(t, err)
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	assert.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveRESP(conn)
+		}
+	}()
+
+	return ln.Addr().String()
+}
+
+// serveRESP reads RESP arrays of bulk strings from conn and answers them.
+func serveRESP(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	for {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		var n int
+		if _, err := fmt.Sscanf(header, "*%d\r\n", &n); err != nil {
+			return
+		}
+
+		var args []string
+		for range n {
+			if _, err := r.ReadString('\n'); err != nil { // bulk string length
+				return
+			}
+			arg, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			args = append(args, strings.TrimSuffix(arg, "\r\n"))
+		}
+
+		reply := "-ERR unknown command\r\n"
+		if strings.EqualFold(args[0], "ping") {
+			reply = "+PONG\r\n"
+		}
+		if _, err := conn.Write([]byte(reply)); err != nil {
+			return
+		}
+	}
+}
+
+func TestNewRedisConnectionWithRedisConfigTLS(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+	_, port, _ := net.SplitHostPort(newTLSRedisServer(t, certFile, keyFile))
+
+	client, err := NewRedisConnectionE(RedisConfig{Addr: "localhost:" + port, CAFile: certFile})
+	assert.NoError(t, err, "Expected the ping to succeed over TLS")
+	client.Close()
+
+	_, err = NewRedisConnectionE(RedisConfig{Addr: "localhost:" + port, TLS: true})
+	assert.ErrorContains(t, err, "certificate", "Expected the self-signed certificate to be rejected without the CA file")
+}
+
+func TestRedisConfigTLSSettings(t *testing.T) {
+	opts, err := RedisConfig{Addr: "cache.local:6380", ServerName: "redis.internal", InsecureSkipVerify: true}.Options()
+	assert.NoError(t, err)
+	assert.Equal(t, "redis.internal", opts.TLSConfig.ServerName)
+	assert.True(t, opts.TLSConfig.InsecureSkipVerify)
+
+	_, err = NewRedisConnectionE(RedisConfig{Addr: "cache.local:6380", CAFile: "missing.pem"})
+	var connErr *ConnectionError
+	assert.ErrorAs(t, err, &connErr)
+	assert.Equal(t, StageConfig, connErr.Stage)
+}
@@ -0,0 +1,38 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+// AcquireConn takes a single connection from the pool of db and runs the session settings on it in order,
+// e.g. "SET search_path TO tenant_42" on PostgreSQL or "SET time_zone = '+00:00'" on MySQL, so that the queries
+// run on the connection share them. The caller must Close the connection to return it to the pool.
+// Session settings outlive Close, so the next user of the pooled connection sees them as well; settings that
+// must not leak should be reset before closing. If a setting fails, the connection is discarded instead of being
+// returned to the pool with the settings half applied.
+func AcquireConn(ctx context.Context, db *sql.DB, settings []string) (*sql.Conn, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+
+	for _, setting := range settings {
+		if _, err := conn.ExecContext(ctx, setting); err != nil {
+			discardConn(conn)
+			return nil, fmt.Errorf("failed to apply session setting %q: %w", setting, err)
+		}
+	}
+
+	return conn, nil
+}
+
+// discardConn closes conn and removes the underlying connection from the pool.
+func discardConn(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error {
+		return driver.ErrBadConn
+	})
+	_ = conn.Close()
+}
@@ -0,0 +1,34 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquireConnAppliesSettings(t *testing.T) {
+	db := openTestSQLite(t)
+	ctx := context.Background()
+
+	conn, err := AcquireConn(ctx, db, []string{"PRAGMA foreign_keys = ON", "PRAGMA busy_timeout = 2500"})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var foreignKeys, busyTimeout int
+	require.NoError(t, conn.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&foreignKeys))
+	require.NoError(t, conn.QueryRowContext(ctx, "PRAGMA busy_timeout").Scan(&busyTimeout))
+	assert.Equal(t, 1, foreignKeys)
+	assert.Equal(t, 2500, busyTimeout)
+}
+
+func TestAcquireConnDiscardsConnectionOnFailedSetting(t *testing.T) {
+	db := openTestSQLite(t)
+
+	conn, err := AcquireConn(context.Background(), db, []string{"PRAGMA foreign_keys = ON", "SET time_zone = '+00:00'"})
+
+	assert.Nil(t, conn)
+	assert.ErrorContains(t, err, `failed to apply session setting "SET time_zone = '+00:00'"`)
+	assert.Zero(t, db.Stats().OpenConnections, "Expected the connection to be removed from the pool")
+}
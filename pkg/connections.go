@@ -0,0 +1,86 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Connections tracks handles opened through the package so they can be closed together on shutdown.
+// Pass it to the connectors with WithConnections, or add handles yourself with Add.
+// The zero value is ready to use and safe for concurrent use.
+type Connections struct {
+	mu      sync.Mutex
+	closers []func(context.Context) error
+}
+
+// WithConnections registers the handle returned by the connector in c once it is connected.
+func WithConnections(c *Connections) Option {
+	return func(o *Options) {
+		o.Connections = c
+	}
+}
+
+// Add tracks conn so that it is closed by Close. Supported are values with a Disconnect(ctx) error method
+// such as *mongo.Client, a Close(ctx) error method, a Close() error method such as *sql.DB and the redis clients,
+// or a plain Close() method such as *gocql.Session.
+func (c *Connections) Add(conn any) error {
+	closeFn, err := closerFor(conn)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closers = append(c.closers, closeFn)
+	return nil
+}
+
+// closerFor returns a function that closes conn, for the handle types supported by Connections.Add.
+func closerFor(conn any) (func(context.Context) error, error) {
+	switch v := conn.(type) {
+	case interface{ Disconnect(context.Context) error }:
+		return v.Disconnect, nil
+	case interface{ Close(context.Context) error }:
+		return v.Close, nil
+	case interface{ Close() error }:
+		return func(context.Context) error { return v.Close() }, nil
+	case interface{ Close() }:
+		return func(context.Context) error {
+			v.Close()
+			return nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("cannot track connection of type %T", conn)
+	}
+}
+
+// Close closes every tracked handle in the reverse order they were added and forgets them.
+// The context bounds the handles that accept one, such as the MongoDB client.
+// All handles are closed even if some fail; the failures are joined into the returned error.
+func (c *Connections) Close(ctx context.Context) error {
+	c.mu.Lock()
+	closers := c.closers
+	c.closers = nil
+	c.mu.Unlock()
+
+	var errs []error
+	for i := len(closers) - 1; i >= 0; i-- {
+		if err := closers[i](ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// track registers conn with the Connections configured in o, if any.
+func (o *Options) track(conn any) {
+	if o.Connections == nil {
+		return
+	}
+	if err := o.Connections.Add(conn); err != nil {
+		o.Logger.Errorf("Failed to track connection: %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeCloser struct {
+	closed bool
+	err    error
+}
+
+func (f *fakeCloser) Close() error {
+	f.closed = true
+	return f.err
+}
+
+func TestConnectionsCloseAggregatesErrors(t *testing.T) {
+	var conns Connections
+
+	ok := &fakeCloser{}
+	failing := &fakeCloser{err: errors.New("close failed")}
+
+	assert.NoError(t, conns.Add(ok))
+	assert.NoError(t, conns.Add(failing))
+
+	err := conns.Close(context.Background())
+
+	assert.True(t, ok.closed)
+	assert.True(t, failing.closed)
+	assert.ErrorIs(t, err, failing.err)
+	assert.NoError(t, conns.Close(context.Background()), "Expected a second Close to be a no-op")
+}
+
+func TestConnectionsAddRejectsUnknownType(t *testing.T) {
+	var conns Connections
+
+	assert.Error(t, conns.Add(42))
+}
+
+func TestWithConnectionsTracksSQLiteConnection(t *testing.T) {
+	var conns Connections
+
+	db := NewSQLiteConnection(":memory:", "", WithConnections(&conns))
+
+	assert.NoError(t, conns.Close(context.Background()))
+	assert.EqualError(t, db.Ping(), "sql: database is closed")
+}
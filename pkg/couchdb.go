@@ -0,0 +1,101 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	kivik "github.com/go-kivik/kivik/v4"
+	"github.com/go-kivik/kivik/v4/couchdb"
+)
+
+// NewCouchDBConnection creates a CouchDB client for serverURL, e.g. http://localhost:5984, and pings the server
+// before returning it. If user is not empty, requests are authenticated with HTTP basic auth.
+// If any error occurs, it logs the error and terminates the application.
+func NewCouchDBConnection(serverURL, user, password string, opts ...Option) *kivik.Client {
+	client, err := NewCouchDBConnectionCtx(context.Background(), serverURL, user, password, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to CouchDB: %v", err.Error())
+	}
+
+	return client
+}
+
+// NewCouchDBConnectionCtx creates a CouchDB client like NewCouchDBConnection,
+// but bounds the ping with ctx and returns an error instead of terminating the application.
+// Credentials from the URL and the password are masked in the returned error.
+func NewCouchDBConnectionCtx(ctx context.Context, serverURL, user, password string, opts ...Option) (*kivik.Client, error) {
+	return connectCouchDB(ctx, serverURL, user, password, newOptions(opts))
+}
+
+// NewCouchDBWithDatabase connects to a CouchDB server like NewCouchDBConnectionCtx and returns the client
+// together with a handle for the database dbName, which must exist.
+func NewCouchDBWithDatabase(ctx context.Context, serverURL, user, password, dbName string, opts ...Option) (*kivik.Client, *kivik.DB, error) {
+	if dbName == "" {
+		return nil, nil, errors.New("no CouchDB database name provided")
+	}
+
+	client, err := NewCouchDBConnectionCtx(ctx, serverURL, user, password, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	exists, err := client.DBExists(ctx, dbName)
+	if err == nil && !exists {
+		err = fmt.Errorf("CouchDB database %q does not exist", dbName)
+	}
+	if err != nil {
+		client.Close()
+		return nil, nil, connectionError("couchdb", StageVerify, err)
+	}
+
+	return client, client.DB(dbName), nil
+}
+
+// connectCouchDB creates the client and pings the server according to the retry policy in o.
+// The client is closed if the ping fails.
+func connectCouchDB(ctx context.Context, serverURL, user, password string, o *Options) (_ *kivik.Client, err error) {
+	host := dsnHost(serverURL)
+	ctx, cancel := o.connectContext(ctx)
+	defer cancel()
+
+	ctx, span := o.startConnectSpan(ctx, "couchdb", host)
+	defer func() {
+		err = redactErr(err, serverURL, password)
+		endSpan(span, err)
+		o.finishConnect("couchdb", host, err)
+	}()
+
+	o.Logger.Infof("Connecting to CouchDB at %s", redactDSN(serverURL))
+
+	var clientOpts []kivik.Option
+	// The custom client goes first, so that the authentication is applied on top of it.
+	if o.HTTPClient != nil {
+		clientOpts = append(clientOpts, couchdb.OptionHTTPClient(o.HTTPClient))
+	}
+	if user != "" {
+		clientOpts = append(clientOpts, couchdb.BasicAuth(user, password))
+	}
+
+	client, err := kivik.New("couch", serverURL, clientOpts...)
+	if err != nil {
+		return nil, connectionError("couchdb", StageOpen, fmt.Errorf("failed to create CouchDB client: %w", err))
+	}
+
+	err = o.ping(ctx, func(ctx context.Context) error {
+		up, err := client.Ping(ctx)
+		if err == nil && !up {
+			err = errors.New("server is not up")
+		}
+		return err
+	})
+	if err != nil {
+		client.Close()
+		return nil, connectionError("couchdb", StagePing, fmt.Errorf("failed to ping CouchDB: %w", err))
+	}
+
+	o.Logger.Infof("Successfully connected to CouchDB")
+	o.track(client)
+
+	return client, nil
+}
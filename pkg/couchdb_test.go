@@ -0,0 +1,81 @@
+package pkg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCouchDBConnection(t *testing.T) {
+	client := NewCouchDBConnection("http://localhost:5984", "admin", "password")
+	defer client.Close()
+
+	up, err := client.Ping(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, up, "Expected CouchDB to answer the ping")
+}
+
+// newCouchDBServer serves the CouchDB endpoints used by the connector: /_up, and HEAD on the database "docs".
+func newCouchDBServer(t *testing.T, up bool) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/_up" && up:
+			_, _ = w.Write([]byte(`{"status":"ok"}`))
+		case r.URL.Path == "/_up":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"status":"maintenance_mode"}`))
+		case r.URL.Path == "/docs" && r.Method == http.MethodHead:
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not_found","reason":"missing"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func TestNewCouchDBConnectionCtxPingsServer(t *testing.T) {
+	client, err := NewCouchDBConnectionCtx(context.Background(), newCouchDBServer(t, true), "admin", "password")
+
+	assert.NoError(t, err)
+	assert.NotNil(t, client)
+	client.Close()
+}
+
+func TestNewCouchDBConnectionCtxServerDown(t *testing.T) {
+	client, err := NewCouchDBConnectionCtx(context.Background(), newCouchDBServer(t, false), "admin", "s3cret")
+
+	assert.Nil(t, client)
+	assert.ErrorContains(t, err, "failed to ping CouchDB")
+	assert.NotContains(t, err.Error(), "s3cret")
+}
+
+func TestNewCouchDBWithDatabase(t *testing.T) {
+	serverURL := newCouchDBServer(t, true)
+
+	client, db, err := NewCouchDBWithDatabase(context.Background(), serverURL, "", "", "docs")
+	assert.NoError(t, err)
+	assert.Equal(t, "docs", db.Name())
+	client.Close()
+
+	client, db, err = NewCouchDBWithDatabase(context.Background(), serverURL, "", "", "missing")
+	assert.Nil(t, client)
+	assert.Nil(t, db)
+	assert.ErrorContains(t, err, `CouchDB database "missing" does not exist`)
+}
+
+func TestNewCouchDBWithDatabaseRequiresName(t *testing.T) {
+	client, db, err := NewCouchDBWithDatabase(context.Background(), "http://localhost:5984", "", "", "")
+
+	assert.Nil(t, client)
+	assert.Nil(t, db)
+	assert.Error(t, err)
+}
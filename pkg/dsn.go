@@ -0,0 +1,55 @@
+package pkg
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// keyValueHost and keyValuePort extract the host and port of key/value DSNs such as "host=db port=5432".
+var (
+	keyValueHost = regexp.MustCompile(`(?:^|\s)host\s*=\s*'?([^\s']+)`)
+	keyValuePort = regexp.MustCompile(`(?:^|\s)port\s*=\s*'?([^\s']+)`)
+)
+
+// dsnHost returns the address a DSN points at, without credentials, for spans and log messages.
+// URL style, MySQL and key/value DSNs are recognized; SQLite DSNs yield the database path.
+func dsnHost(dsn string) string {
+	if _, rest, ok := strings.Cut(dsn, "://"); ok {
+		authority := rest
+		if i := strings.IndexAny(rest, "/?"); i >= 0 {
+			authority = rest[:i]
+		}
+		if at := strings.LastIndex(authority, "@"); at >= 0 {
+			authority = authority[at+1:]
+		}
+		if authority == "" {
+			// PostgreSQL URLs name a Unix socket directory with a host parameter instead.
+			if u, err := url.Parse(dsn); err == nil {
+				return u.Query().Get("host")
+			}
+		}
+		return authority
+	}
+
+	if path, ok := strings.CutPrefix(dsn, "file:"); ok {
+		path, _, _ = strings.Cut(path, "?")
+		return path
+	}
+
+	if m := keyValueHost.FindStringSubmatch(dsn); m != nil {
+		if p := keyValuePort.FindStringSubmatch(dsn); p != nil {
+			return net.JoinHostPort(m[1], p[1])
+		}
+		return m[1]
+	}
+
+	if cfg, err := mysql.ParseDSN(dsn); err == nil {
+		return cfg.Addr
+	}
+
+	return ""
+}
@@ -0,0 +1,44 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	_ "github.com/marcboeker/go-duckdb/v2"
+)
+
+// DuckDBMemory is the path that selects an in-memory DuckDB database.
+const DuckDBMemory = ":memory:"
+
+// NewDuckDBConnection opens the DuckDB database at path, or an in-memory database for DuckDBMemory,
+// and returns a pinged *sql.DB. Missing parent directories of a file-backed database are created,
+// like NewSQLiteConnectionWithConfig does, and DuckDB creates the file itself.
+// If any error occurs, it logs the error and terminates the application.
+func NewDuckDBConnection(path string, opts ...Option) *sql.DB {
+	db, err := NewDuckDBConnectionE(path, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to the DuckDB database: %v", err.Error())
+	}
+
+	return db
+}
+
+// NewDuckDBConnectionE opens a DuckDB database like NewDuckDBConnection,
+// but returns an error instead of terminating the application.
+func NewDuckDBConnectionE(path string, opts ...Option) (*sql.DB, error) {
+	if path == "" {
+		return nil, errors.New("no DuckDB database path provided")
+	}
+
+	dsn := path
+	if path == DuckDBMemory {
+		// The driver opens an in-memory database for an empty DSN.
+		dsn = ""
+	} else if err := ensureParentDir(path, 0); err != nil {
+		return nil, connectionError("duckdb", StageOpen, fmt.Errorf("failed to create DuckDB database directory: %w", err))
+	}
+
+	return openSQL(context.Background(), "DuckDB", "duckdb", "duckdb", dsn, PoolConfig{}, newOptions(opts))
+}
@@ -0,0 +1,40 @@
+package pkg
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDuckDBConnectionInMemory(t *testing.T) {
+	db := NewDuckDBConnection(DuckDBMemory)
+	defer db.Close()
+
+	var answer int
+	assert.NoError(t, db.QueryRow("SELECT 21 * 2").Scan(&answer))
+	assert.Equal(t, 42, answer)
+}
+
+func TestNewDuckDBConnectionCreatesParentDirectories(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "analytics", "warehouse", "events.duckdb")
+
+	db, err := NewDuckDBConnectionE(path)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("CREATE TABLE events AS SELECT range AS id FROM range(10)")
+	assert.NoError(t, err)
+
+	var count int
+	assert.NoError(t, db.QueryRow("SELECT count(*) FROM events").Scan(&count))
+	assert.Equal(t, 10, count)
+	assert.FileExists(t, path)
+}
+
+func TestNewDuckDBConnectionERequiresPath(t *testing.T) {
+	db, err := NewDuckDBConnectionE("")
+
+	assert.Nil(t, db)
+	assert.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// DynamoDBConfig describes how to reach DynamoDB.
+type DynamoDBConfig struct {
+	// Region is the AWS region, such as "eu-west-1". Defaults to the region of the shared AWS configuration
+	// and the AWS_REGION environment variable.
+	Region string `json:"region" yaml:"region"`
+	// Endpoint overrides the service endpoint, for example "http://localhost:8000" for dynamodb-local.
+	Endpoint string `json:"endpoint" yaml:"endpoint"`
+	// AccessKeyID, SecretAccessKey and SessionToken set static credentials. When AccessKeyID is empty the
+	// default credential chain is used: environment variables, shared files, and the instance or task role.
+	AccessKeyID     string `json:"access_key_id" yaml:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key" yaml:"secret_access_key"`
+	SessionToken    string `json:"session_token" yaml:"session_token"`
+}
+
+// NewDynamoDBClient creates a DynamoDB client from cfg and checks connectivity by listing at most one table.
+// If successful, it returns the client to interact with DynamoDB.
+// If any error occurs, it logs the error and terminates the application.
+func NewDynamoDBClient(ctx context.Context, cfg DynamoDBConfig, opts ...Option) *dynamodb.Client {
+	client, err := NewDynamoDBClientE(ctx, cfg, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to DynamoDB: %v", err.Error())
+	}
+
+	return client
+}
+
+// NewDynamoDBClientE creates a DynamoDB client like NewDynamoDBClient,
+// but returns an error instead of terminating the application.
+func NewDynamoDBClientE(ctx context.Context, cfg DynamoDBConfig, opts ...Option) (*dynamodb.Client, error) {
+	return connectDynamoDB(ctx, cfg, newOptions(opts))
+}
+
+// connectDynamoDB loads the AWS configuration, creates the client and checks it according to the retry policy in o.
+func connectDynamoDB(ctx context.Context, cfg DynamoDBConfig, o *Options) (_ *dynamodb.Client, err error) {
+	host := cfg.Region
+	if cfg.Endpoint != "" {
+		host = dsnHost(cfg.Endpoint)
+	}
+	ctx, cancel := o.connectContext(ctx)
+	defer cancel()
+
+	ctx, span := o.startConnectSpan(ctx, "dynamodb", host)
+	defer func() {
+		err = redactErr(err, "", cfg.SecretAccessKey)
+		endSpan(span, err)
+		o.finishConnect("dynamodb", host, err)
+	}()
+
+	loadOpts := []func(*config.LoadOptions) error{}
+	if cfg.Region != "" {
+		loadOpts = append(loadOpts, config.WithRegion(cfg.Region))
+	}
+	if cfg.AccessKeyID != "" {
+		loadOpts = append(loadOpts, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, cfg.SessionToken)))
+	}
+
+	if o.HTTPClient != nil {
+		loadOpts = append(loadOpts, config.WithHTTPClient(o.HTTPClient))
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
+	if err != nil {
+		return nil, connectionError("dynamodb", StageConfig, fmt.Errorf("failed to load AWS configuration: %w", err))
+	}
+	if awsCfg.Region == "" {
+		return nil, connectionError("dynamodb", StageConfig, errors.New("no AWS region configured for DynamoDB"))
+	}
+
+	client := dynamodb.NewFromConfig(awsCfg, func(do *dynamodb.Options) {
+		if cfg.Endpoint != "" {
+			do.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+	})
+
+	if cfg.Endpoint != "" {
+		o.Logger.Infof("Connecting to DynamoDB at %s", cfg.Endpoint)
+	} else {
+		o.Logger.Infof("Connecting to DynamoDB in %s", awsCfg.Region)
+	}
+
+	err = o.ping(ctx, func(ctx context.Context) error {
+		_, err := client.ListTables(ctx, &dynamodb.ListTablesInput{Limit: aws.Int32(1)})
+		return err
+	})
+	if err != nil {
+		return nil, connectionError("dynamodb", StagePing, fmt.Errorf("failed to list DynamoDB tables: %w", err))
+	}
+
+	o.Logger.Infof("Successfully connected to DynamoDB")
+
+	return client, nil
+}
@@ -0,0 +1,80 @@
+package pkg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDynamoDBClient(t *testing.T) {
+	client := NewDynamoDBClient(context.Background(), DynamoDBConfig{
+		Region:          "us-east-1",
+		Endpoint:        "http://localhost:8000",
+		AccessKeyID:     "local",
+		SecretAccessKey: "local",
+	})
+
+	assert.NotNil(t, client)
+}
+
+func newDynamoDBServer(t *testing.T, status int, body string) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DynamoDB_20120810.ListTables", r.Header.Get("X-Amz-Target"))
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func TestNewDynamoDBClientEWithEndpoint(t *testing.T) {
+	endpoint := newDynamoDBServer(t, http.StatusOK, `{"TableNames":[]}`)
+
+	client, err := NewDynamoDBClientE(context.Background(), DynamoDBConfig{
+		Region:          "us-east-1",
+		Endpoint:        endpoint,
+		AccessKeyID:     "local",
+		SecretAccessKey: "local",
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, client)
+}
+
+func TestNewDynamoDBClientERedactsSecret(t *testing.T) {
+	endpoint := newDynamoDBServer(t, http.StatusBadRequest,
+		`{"__type":"com.amazonaws.dynamodb.v20120810#AccessDeniedException","message":"denied for s3cret-key"}`)
+
+	client, err := NewDynamoDBClientE(context.Background(), DynamoDBConfig{
+		Region:          "us-east-1",
+		Endpoint:        endpoint,
+		AccessKeyID:     "local",
+		SecretAccessKey: "s3cret-key",
+	})
+
+	assert.Nil(t, client)
+	assert.ErrorContains(t, err, "failed to list DynamoDB tables")
+	assert.NotContains(t, err.Error(), "s3cret-key")
+
+	var connErr *ConnectionError
+	assert.ErrorAs(t, err, &connErr)
+	assert.Equal(t, StagePing, connErr.Stage)
+}
+
+func TestNewDynamoDBClientERequiresRegion(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	t.Setenv("AWS_CONFIG_FILE", t.TempDir()+"/config")
+
+	client, err := NewDynamoDBClientE(context.Background(), DynamoDBConfig{AccessKeyID: "local", SecretAccessKey: "local"})
+
+	assert.Nil(t, client)
+	assert.ErrorContains(t, err, "no AWS region configured")
+}
@@ -0,0 +1,107 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// ElasticsearchConfig describes an Elasticsearch cluster and how to authenticate with it.
+// Set either Username and Password for basic auth, or APIKey.
+type ElasticsearchConfig struct {
+	// Addresses lists the node URLs, e.g. "https://localhost:9200".
+	Addresses []string `json:"addresses" yaml:"addresses"`
+	Username  string   `json:"username" yaml:"username"`
+	Password  string   `json:"password" yaml:"password"`
+	// APIKey is the base64 encoded API key and takes precedence over basic auth.
+	APIKey string `json:"api_key" yaml:"api_key"`
+}
+
+// NewElasticsearchConnection creates a typed Elasticsearch client for the given node addresses or config
+// and validates it with an Info call before returning it. The client performs the Elasticsearch
+// product check, so OpenSearch clusters are rejected.
+// If any error occurs, it logs the error and terminates the application.
+func NewElasticsearchConnection[T []string | ElasticsearchConfig](cfg T, opts ...Option) *elasticsearch.TypedClient {
+	client, err := NewElasticsearchConnectionCtx(context.Background(), cfg, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to Elasticsearch: %v", err.Error())
+	}
+
+	return client
+}
+
+// NewElasticsearchConnectionCtx creates a typed Elasticsearch client like NewElasticsearchConnection,
+// but bounds the Info call with ctx and returns an error instead of terminating the application.
+func NewElasticsearchConnectionCtx[T []string | ElasticsearchConfig](ctx context.Context, cfg T, opts ...Option) (*elasticsearch.TypedClient, error) {
+	var esCfg ElasticsearchConfig
+
+	switch v := any(cfg).(type) {
+	case []string:
+		esCfg.Addresses = v
+	case ElasticsearchConfig:
+		esCfg = v
+	default:
+		return nil, fmt.Errorf("invalid config type: %T", v)
+	}
+
+	if len(esCfg.Addresses) == 0 {
+		return nil, errors.New("no Elasticsearch addresses provided")
+	}
+
+	return connectElasticsearch(ctx, esCfg, newOptions(opts))
+}
+
+// connectElasticsearch creates the client and calls Info according to the retry policy in o.
+func connectElasticsearch(ctx context.Context, cfg ElasticsearchConfig, o *Options) (_ *elasticsearch.TypedClient, err error) {
+	addresses := strings.Join(cfg.Addresses, ",")
+
+	host := dsnHost(cfg.Addresses[0])
+	ctx, cancel := o.connectContext(ctx)
+	defer cancel()
+
+	ctx, span := o.startConnectSpan(ctx, "elasticsearch", host)
+	defer func() {
+		err = redactErr(err, "", cfg.Password, cfg.APIKey)
+		endSpan(span, err)
+		o.finishConnect("elasticsearch", host, err)
+	}()
+
+	o.Logger.Infof("Connecting to Elasticsearch at %s", addresses)
+
+	esCfg := elasticsearch.Config{
+		Addresses: cfg.Addresses,
+		Username:  cfg.Username,
+		Password:  cfg.Password,
+		APIKey:    cfg.APIKey,
+	}
+	if o.HTTPClient != nil {
+		esCfg.Transport = clientTransport{client: o.HTTPClient}
+	}
+
+	client, err := elasticsearch.NewTypedClient(esCfg)
+	if err != nil {
+		return nil, connectionError("elasticsearch", StageOpen, fmt.Errorf("failed to create Elasticsearch client: %w", err))
+	}
+
+	var version string
+	err = o.ping(ctx, func(ctx context.Context) error {
+		info, err := client.Info().Do(ctx)
+		if err != nil {
+			return err
+		}
+		version = info.Version.Int
+		return nil
+	})
+	if err != nil {
+		_ = client.Close(context.Background())
+		return nil, connectionError("elasticsearch", StagePing, fmt.Errorf("failed to reach Elasticsearch: %w", err))
+	}
+
+	o.Logger.Infof("Successfully connected to Elasticsearch %s", version)
+	o.track(client)
+
+	return client, nil
+}
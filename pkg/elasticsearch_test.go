@@ -0,0 +1,55 @@
+package pkg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewElasticsearchConnection(t *testing.T) {
+	client := NewElasticsearchConnection([]string{"http://localhost:9200"})
+
+	ok, err := client.Ping().Do(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, ok, "Expected Elasticsearch to answer the ping")
+}
+
+func TestNewElasticsearchConnectionCtxBasicAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "elastic" || password != "changeme" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		_, _ = w.Write([]byte(`{"cluster_name":"test","version":{"number":"8.15.0","build_flavor":"default"},"tagline":"You Know, for Search"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewElasticsearchConnectionCtx(context.Background(), ElasticsearchConfig{
+		Addresses: []string{server.URL},
+		Username:  "elastic",
+		Password:  "changeme",
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, client)
+
+	_, err = NewElasticsearchConnectionCtx(context.Background(), ElasticsearchConfig{
+		Addresses: []string{server.URL},
+		Username:  "elastic",
+		Password:  "wrong-password",
+	})
+	assert.ErrorContains(t, err, "failed to reach Elasticsearch")
+	assert.NotContains(t, err.Error(), "wrong-password")
+}
+
+func TestNewElasticsearchConnectionCtxRequiresAddresses(t *testing.T) {
+	client, err := NewElasticsearchConnectionCtx(context.Background(), []string{})
+
+	assert.Nil(t, client)
+	assert.EqualError(t, err, "no Elasticsearch addresses provided")
+}
@@ -0,0 +1,77 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresConfigFromEnv(t *testing.T) {
+	t.Setenv("PGHOST", "db.local")
+	t.Setenv("PGPORT", "6432")
+	t.Setenv("PGUSER", "app")
+	t.Setenv("PGPASSWORD", "s3cr:t@")
+	t.Setenv("PGDATABASE", "orders")
+	t.Setenv("PGSSLMODE", "require")
+
+	cfg, err := PostgresConfigFromEnv()
+
+	assert.NoError(t, err)
+	assert.Equal(t, PostgresConfig{
+		Host:     "db.local",
+		Port:     6432,
+		User:     "app",
+		Password: "s3cr:t@",
+		DBName:   "orders",
+		SSLMode:  "require",
+	}, cfg)
+}
+
+func TestPostgresConfigFromEnvMissingKey(t *testing.T) {
+	t.Setenv("PGHOST", "db.local")
+	t.Setenv("PGUSER", "app")
+	t.Setenv("PGDATABASE", "")
+
+	_, err := PostgresConfigFromEnv()
+
+	assert.EqualError(t, err, "missing required environment variable PGDATABASE")
+}
+
+func TestPostgresConfigFromEnvInvalidPort(t *testing.T) {
+	t.Setenv("PGHOST", "db.local")
+	t.Setenv("PGUSER", "app")
+	t.Setenv("PGDATABASE", "orders")
+	t.Setenv("PGPORT", "five")
+
+	_, err := NewPostgresFromEnvE()
+
+	assert.ErrorContains(t, err, "invalid PGPORT")
+}
+
+func TestNewRedisFromEnvE(t *testing.T) {
+	t.Setenv("REDIS_URL", "")
+	_, err := NewRedisFromEnvE()
+	assert.EqualError(t, err, "missing required environment variable REDIS_URL")
+
+	t.Setenv("REDIS_URL", "http://:hunter2@localhost:6379")
+	_, err = NewRedisFromEnvE()
+	assert.ErrorContains(t, err, "invalid REDIS_URL")
+	assert.NotContains(t, err.Error(), "hunter2")
+}
+
+func TestNewSQLFromEnvE(t *testing.T) {
+	t.Setenv("DATABASE_URL", "")
+	_, err := NewSQLFromEnvE()
+	assert.EqualError(t, err, "missing required environment variable DATABASE_URL")
+
+	t.Setenv("DATABASE_URL", "redis://localhost:6379")
+	_, err = NewSQLFromEnvE()
+	assert.ErrorIs(t, err, ErrInvalidScheme)
+
+	t.Setenv("DATABASE_URL", "sqlite://"+SQLiteMemory)
+	db, err := NewSQLFromEnvE()
+	assert.NoError(t, err)
+	if assert.NotNil(t, db) {
+		assert.NoError(t, db.Close())
+	}
+}
@@ -0,0 +1,50 @@
+package pkg
+
+import "errors"
+
+// Stage names the step of establishing a connection that failed.
+type Stage string
+
+const (
+	// StageConfig means the DSN, URI or config could not be parsed or validated.
+	StageConfig Stage = "config"
+	// StageOpen means the client or driver handle could not be created.
+	StageOpen Stage = "open"
+	// StagePing means the handle was created but the server could not be reached.
+	StagePing Stage = "ping"
+	// StageVerify means the server was reached but failed a check requested by an option.
+	StageVerify Stage = "verify"
+)
+
+// ErrInvalidScheme is wrapped by errors for connection URIs whose scheme is not supported by the connector.
+var ErrInvalidScheme = errors.New("invalid scheme")
+
+// ConnectionError is returned by the error-returning connectors when a connection cannot be established.
+// Use errors.As to tell configuration mistakes apart from unreachable servers:
+//
+//	var connErr *pkg.ConnectionError
+//	if errors.As(err, &connErr) && connErr.Stage == pkg.StagePing {
+//		// the server is unreachable, try again later
+//	}
+type ConnectionError struct {
+	// Driver is the backend the connector talks to, e.g. "mysql", "postgresql" or "mongodb".
+	Driver string
+	Stage  Stage
+	Err    error
+}
+
+func (e *ConnectionError) Error() string {
+	return e.Driver + ": " + e.Err.Error()
+}
+
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+// connectionError wraps err in a ConnectionError, or returns nil if err is nil.
+func connectionError(driver string, stage Stage, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ConnectionError{Driver: driver, Stage: stage, Err: err}
+}
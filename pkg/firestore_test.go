@@ -0,0 +1,37 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFirestoreConnection(t *testing.T) {
+	client, err := NewFirestoreConnectionE(context.Background(), "test-project", WithEmulator("localhost:8080"),
+		WithConnectTimeout(2*time.Second))
+	if err != nil {
+		t.Skipf("Firestore emulator is not reachable: %v", err)
+	}
+	defer client.Close()
+
+	assert.NotNil(t, client)
+}
+
+func TestNewFirestoreConnectionEEmptyProject(t *testing.T) {
+	client, err := NewFirestoreConnectionE(context.Background(), "", WithEmulator("localhost:8080"))
+
+	assert.Nil(t, client)
+	assert.ErrorContains(t, err, "failed to create Firestore client")
+}
+
+func TestNewFirestoreConnectionEUnreachableEmulator(t *testing.T) {
+	client, err := NewFirestoreConnectionE(context.Background(), "test-project",
+		WithEmulator("127.0.0.1:1"), WithConnectTimeout(200*time.Millisecond))
+
+	assert.Nil(t, client)
+	var connErr *ConnectionError
+	assert.ErrorAs(t, err, &connErr)
+	assert.Equal(t, StagePing, connErr.Stage)
+}
@@ -0,0 +1,69 @@
+package pkg
+
+import (
+	"strings"
+
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// WithGoogleClientOptions passes opts to the Google Cloud client created by the connector, for example
+// option.WithCredentialsFile to authenticate with a service account key instead of the default credentials.
+func WithGoogleClientOptions(opts ...option.ClientOption) Option {
+	return func(o *Options) {
+		o.google.clientOptions = append(o.google.clientOptions, opts...)
+	}
+}
+
+// WithEmulator connects the Google Cloud connector to a local emulator listening on host, e.g. "localhost:9010",
+// over plain gRPC and without authentication.
+func WithEmulator(host string) Option {
+	return func(o *Options) {
+		o.google.emulatorHost = host
+	}
+}
+
+// WithBigQueryLocation sets the location, e.g. "EU" or "europe-west4", that BigQuery jobs created through the
+// client of NewBigQueryConnection run in. It must match the location of the datasets the jobs use.
+func WithBigQueryLocation(location string) Option {
+	return func(o *Options) {
+		o.google.location = location
+	}
+}
+
+// googleSettings holds the options of the Google Cloud connectors.
+type googleSettings struct {
+	clientOptions []option.ClientOption
+	emulatorHost  string
+	location      string
+}
+
+// options returns the client options for the configured credentials and emulator.
+// The emulator options come first, so explicitly passed client options take precedence.
+func (g googleSettings) options() []option.ClientOption {
+	if g.emulatorHost == "" {
+		return g.clientOptions
+	}
+
+	host := strings.TrimPrefix(strings.TrimPrefix(g.emulatorHost, "http://"), "https://")
+	return append([]option.ClientOption{
+		option.WithEndpoint("passthrough:///" + host),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+		option.WithoutAuthentication(),
+	}, g.clientOptions...)
+}
+
+// httpOptions returns the client options like options, for the Google Cloud clients that use the REST API.
+// Their emulators serve plain HTTP, so the endpoint is an http:// URL.
+func (g googleSettings) httpOptions() []option.ClientOption {
+	if g.emulatorHost == "" {
+		return g.clientOptions
+	}
+
+	host := strings.TrimPrefix(strings.TrimPrefix(g.emulatorHost, "http://"), "https://")
+	return append([]option.ClientOption{
+		option.WithEndpoint("http://" + host),
+		option.WithoutAuthentication(),
+	}, g.clientOptions...)
+}
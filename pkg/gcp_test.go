@@ -0,0 +1,19 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/option"
+)
+
+func TestGoogleSettingsOptions(t *testing.T) {
+	o := newOptions([]Option{WithGoogleClientOptions(option.WithUserAgent("test"))})
+	assert.Len(t, o.google.options(), 1)
+
+	o = newOptions([]Option{WithEmulator("http://localhost:8080"), WithGoogleClientOptions(option.WithUserAgent("test"))})
+	opts := o.google.options()
+	assert.Len(t, opts, 4)
+	assert.Equal(t, option.WithEndpoint("passthrough:///localhost:8080"), opts[0])
+	assert.Equal(t, option.WithUserAgent("test"), opts[3])
+}
@@ -0,0 +1,74 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	gormmysql "gorm.io/driver/mysql"
+	gormpostgres "gorm.io/driver/postgres"
+	gormsqlite "gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// NewGormConnection opens a GORM database on top of a connection established with the package's own
+// MySQL, PostgreSQL or SQLite constructors, selected by kind, so the DSN handling and ping are the same.
+// For KindSQLite, dsn is a file path, SQLiteMemory or a "file:" URI. gormCfg controls GORM itself,
+// e.g. its logger and naming strategy, and may be nil.
+// If any error occurs, it logs the error and terminates the application.
+func NewGormConnection(kind Kind, dsn string, gormCfg *gorm.Config, opts ...Option) *gorm.DB {
+	db, err := NewGormConnectionE(kind, dsn, gormCfg, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to open the GORM database: %v", err.Error())
+	}
+
+	return db
+}
+
+// NewGormConnectionE opens a GORM database like NewGormConnection,
+// but returns an error instead of terminating the application.
+func NewGormConnectionE(kind Kind, dsn string, gormCfg *gorm.Config, opts ...Option) (*gorm.DB, error) {
+	var (
+		sqlDB     *sql.DB
+		dialector gorm.Dialector
+		err       error
+	)
+
+	switch kind {
+	case KindMySQL:
+		if sqlDB, err = NewSQLDBConnectionE(dsn, opts...); err == nil {
+			dialector = gormmysql.New(gormmysql.Config{Conn: sqlDB})
+		}
+	case KindPostgres:
+		if sqlDB, err = NewPostgresDBConnectionE(dsn, opts...); err == nil {
+			dialector = gormpostgres.New(gormpostgres.Config{Conn: sqlDB})
+		}
+	case KindSQLite:
+		if strings.HasPrefix(dsn, "file:") {
+			sqlDB, err = openSQLiteDSN(context.Background(), dsn, newOptions(opts))
+		} else {
+			sqlDB, err = NewSQLiteConnectionWithConfigE(SQLiteConfig{Path: dsn}, opts...)
+		}
+		if err == nil {
+			dialector = gormsqlite.New(gormsqlite.Config{Conn: sqlDB})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported GORM database kind %q", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if gormCfg == nil {
+		gormCfg = &gorm.Config{}
+	}
+
+	db, err := gorm.Open(dialector, gormCfg)
+	if err != nil {
+		_ = sqlDB.Close()
+		return nil, fmt.Errorf("failed to open GORM database: %w", err)
+	}
+
+	return db, nil
+}
@@ -0,0 +1,48 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// HealthCheckSQL pings db within ctx and reports whether it is reachable.
+// It neither logs nor exits, which makes it suitable for readiness probes.
+func HealthCheckSQL(ctx context.Context, db *sql.DB) error {
+	return db.PingContext(ctx)
+}
+
+// HealthCheckRedis pings a Redis client within ctx and reports whether it is reachable.
+// Any client works, including cluster and failover clients.
+func HealthCheckRedis(ctx context.Context, client redis.UniversalClient) error {
+	return client.Ping(ctx).Err()
+}
+
+// HealthCheckMongo pings the primary of a MongoDB deployment within ctx and reports whether it is reachable.
+func HealthCheckMongo(ctx context.Context, client *mongo.Client) error {
+	return client.Ping(ctx, nil)
+}
+
+// WaitForSQL blocks until db answers a ping, retrying according to retry until the attempts are exhausted
+// or ctx is done. Options only affect logging of failed attempts.
+func WaitForSQL(ctx context.Context, db *sql.DB, retry RetryConfig, opts ...Option) error {
+	return retry.do(ctx, newOptions(opts).Logger, func(ctx context.Context) error {
+		return HealthCheckSQL(ctx, db)
+	})
+}
+
+// WaitForRedis blocks until client answers a ping, retrying like WaitForSQL.
+func WaitForRedis(ctx context.Context, client redis.UniversalClient, retry RetryConfig, opts ...Option) error {
+	return retry.do(ctx, newOptions(opts).Logger, func(ctx context.Context) error {
+		return HealthCheckRedis(ctx, client)
+	})
+}
+
+// WaitForMongo blocks until the primary of client answers a ping, retrying like WaitForSQL.
+func WaitForMongo(ctx context.Context, client *mongo.Client, retry RetryConfig, opts ...Option) error {
+	return retry.do(ctx, newOptions(opts).Logger, func(ctx context.Context) error {
+		return HealthCheckMongo(ctx, client)
+	})
+}
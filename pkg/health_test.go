@@ -0,0 +1,55 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthCheckSQL(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+
+	assert.NoError(t, HealthCheckSQL(context.Background(), db))
+
+	db.Close()
+	assert.Error(t, HealthCheckSQL(context.Background(), db), "Expected an error for a closed database")
+}
+
+func TestHealthCheckRedisRespectsContext(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: newSilentListener(t), ContextTimeoutEnabled: true})
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	err := HealthCheckRedis(ctx, client)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "Expected context.DeadlineExceeded, got %v", err)
+}
+
+func TestWaitForSQL(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+
+	assert.NoError(t, WaitForSQL(context.Background(), db, RetryConfig{Attempts: 3}))
+
+	db.Close()
+	err = WaitForSQL(context.Background(), db, RetryConfig{Attempts: 2, InitialDelay: time.Millisecond})
+	assert.Error(t, err, "Expected an error once the attempts are exhausted")
+}
+
+func TestWaitForRedisStopsWhenContextIsDone(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := WaitForRedis(ctx, client, RetryConfig{Attempts: 100, InitialDelay: 20 * time.Millisecond})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "Expected context.DeadlineExceeded, got %v", err)
+}
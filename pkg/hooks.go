@@ -0,0 +1,47 @@
+package pkg
+
+import "errors"
+
+// WithOnConnect calls fn after the connector has connected to and pinged the server.
+// driver is the backend name, e.g. "mysql" or "mongodb", and host the server address.
+func WithOnConnect(fn func(driver, host string)) Option {
+	return func(o *Options) {
+		o.OnConnect = fn
+	}
+}
+
+// WithOnError calls fn when the connector fails, with the Stage that failed, e.g. to count failures
+// per stage in a metrics library without this package depending on it.
+func WithOnError(fn func(stage Stage, err error)) Option {
+	return func(o *Options) {
+		o.OnError = fn
+	}
+}
+
+// finishConnect calls the OnConnect or OnError hook for the outcome of connecting to host.
+func (o *Options) finishConnect(driver, host string, err error) {
+	if err != nil {
+		o.failed(err)
+		return
+	}
+	if o.OnConnect != nil {
+		o.OnConnect(driver, host)
+	}
+}
+
+// failed calls the OnError hook with the stage of err, which defaults to StageOpen
+// for errors that are not a ConnectionError, and returns err.
+func (o *Options) failed(err error) error {
+	if o.OnError == nil || err == nil {
+		return err
+	}
+
+	stage := StageOpen
+	var connErr *ConnectionError
+	if errors.As(err, &connErr) {
+		stage = connErr.Stage
+	}
+	o.OnError(stage, err)
+
+	return err
+}
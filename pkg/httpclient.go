@@ -0,0 +1,23 @@
+package pkg
+
+import "net/http"
+
+// WithHTTPClient makes the connectors for HTTP based databases, such as Elasticsearch, InfluxDB, CouchDB and
+// DynamoDB, send their requests through c, so that proxies, TLS settings and timeouts can be controlled.
+// Connectors for other databases ignore it. The AWS SDK cannot add the CA bundle from AWS_CA_BUNDLE to a custom
+// client, so configure its TLS settings on c instead.
+func WithHTTPClient(c *http.Client) Option {
+	return func(o *Options) {
+		o.HTTPClient = c
+	}
+}
+
+// clientTransport adapts an *http.Client to the http.RoundTripper expected by drivers that only accept a transport,
+// so that the client's timeout, redirect policy and cookie jar apply as well.
+type clientTransport struct {
+	client *http.Client
+}
+
+func (t clientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.client.Do(req)
+}
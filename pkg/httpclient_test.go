@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingTransport counts the requests sent through it before passing them to the default transport.
+type countingTransport struct {
+	requests atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestWithHTTPClientElasticsearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		_, _ = w.Write([]byte(`{"cluster_name":"test","version":{"number":"8.15.0","build_flavor":"default"},"tagline":"You Know, for Search"}`))
+	}))
+	defer server.Close()
+
+	transport := &countingTransport{}
+	_, err := NewElasticsearchConnectionCtx(context.Background(), ElasticsearchConfig{Addresses: []string{server.URL}},
+		WithHTTPClient(&http.Client{Transport: transport}))
+
+	assert.NoError(t, err)
+	assert.Positive(t, transport.requests.Load())
+}
+
+func TestWithHTTPClientInfluxDB(t *testing.T) {
+	transport := &countingTransport{}
+	db, err := NewInfluxDBConnectionCtx(context.Background(), newInfluxHealthServer(t, "pass"), "token", "org", "bucket",
+		WithHTTPClient(&http.Client{Transport: transport}))
+
+	assert.NoError(t, err)
+	db.Close()
+	assert.Positive(t, transport.requests.Load())
+}
+
+func TestWithHTTPClientCouchDB(t *testing.T) {
+	transport := &countingTransport{}
+	client, err := NewCouchDBConnectionCtx(context.Background(), newCouchDBServer(t, true), "admin", "password",
+		WithHTTPClient(&http.Client{Transport: transport}))
+
+	assert.NoError(t, err)
+	client.Close()
+	assert.Positive(t, transport.requests.Load())
+}
+
+func TestWithHTTPClientCouchDBKeepsBasicAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "admin" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewCouchDBConnectionCtx(context.Background(), server.URL, "admin", "password",
+		WithHTTPClient(&http.Client{Transport: &countingTransport{}}))
+
+	assert.NoError(t, err)
+	client.Close()
+}
+
+func TestWithHTTPClientDynamoDB(t *testing.T) {
+	// The SDK cannot apply a CA bundle to a custom client.
+	t.Setenv("AWS_CA_BUNDLE", "")
+
+	transport := &countingTransport{}
+	_, err := NewDynamoDBClientE(context.Background(), DynamoDBConfig{
+		Region:          "us-east-1",
+		Endpoint:        newDynamoDBServer(t, http.StatusOK, `{"TableNames":[]}`),
+		AccessKeyID:     "local",
+		SecretAccessKey: "local",
+	}, WithHTTPClient(&http.Client{Transport: transport}))
+
+	assert.NoError(t, err)
+	assert.Positive(t, transport.requests.Load())
+}
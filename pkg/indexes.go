@@ -0,0 +1,57 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// mongoErrNamespaceExists is the code MongoDB returns when creating a collection that exists already.
+const mongoErrNamespaceExists = 48
+
+// EnsureMongoIndexes creates the indexes listed per collection name in db, creating the collections too if they
+// don't exist yet. A collection with no indexes listed is only created. Indexes that exist already with the same
+// keys and options are left as they are, which makes it safe to call on every start of a service; an existing
+// index with the same name but a different definition is an error, since MongoDB does not alter indexes in place.
+// Every collection is attempted, and the errors of the failed ones are joined.
+func EnsureMongoIndexes(ctx context.Context, db *mongo.Database, indexes map[string][]mongo.IndexModel) error {
+	names := make([]string, 0, len(indexes))
+	for name := range indexes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var errs []error
+	for _, name := range names {
+		if err := ensureMongoCollection(ctx, db, name, indexes[name]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ensureMongoCollection creates the collection name in db with models.
+func ensureMongoCollection(ctx context.Context, db *mongo.Database, name string, models []mongo.IndexModel) error {
+	if len(models) == 0 {
+		if err := db.CreateCollection(ctx, name); err != nil && !isNamespaceExists(err) {
+			return fmt.Errorf("failed to create MongoDB collection %q: %w", name, err)
+		}
+		return nil
+	}
+
+	// createIndexes creates the collection implicitly and does nothing for indexes that exist already.
+	if _, err := db.Collection(name).Indexes().CreateMany(ctx, models); err != nil {
+		return fmt.Errorf("failed to create indexes on MongoDB collection %q: %w", name, err)
+	}
+	return nil
+}
+
+// isNamespaceExists reports whether err is the NamespaceExists error MongoDB returns for an existing collection.
+func isNamespaceExists(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && cmdErr.Code == mongoErrNamespaceExists
+}
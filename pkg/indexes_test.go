@@ -0,0 +1,58 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+func TestEnsureMongoIndexes(t *testing.T) {
+	client := NewMongoDBConnection("mongodb://localhost:27017")
+	ctx := context.Background()
+	defer client.Disconnect(ctx)
+
+	db := client.Database("test_ensure_indexes")
+	defer db.Drop(ctx)
+
+	indexes := map[string][]mongo.IndexModel{
+		"users": {
+			{Keys: bson.D{{Key: "email", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "created_at", Value: -1}}},
+		},
+		"audit": nil,
+	}
+
+	assert.NoError(t, EnsureMongoIndexes(ctx, db, indexes))
+	assert.NoError(t, EnsureMongoIndexes(ctx, db, indexes), "Expected existing indexes to be accepted")
+
+	names, err := db.ListCollectionNames(ctx, bson.D{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"users", "audit"}, names)
+}
+
+func TestEnsureMongoIndexesUnreachable(t *testing.T) {
+	client, err := mongo.Connect(options.Client().ApplyURI("mongodb://127.0.0.1:1").SetServerSelectionTimeout(100 * time.Millisecond))
+	require.NoError(t, err)
+	defer client.Disconnect(context.Background())
+
+	err = EnsureMongoIndexes(context.Background(), client.Database("app"), map[string][]mongo.IndexModel{
+		"users":  {{Keys: bson.D{{Key: "email", Value: 1}}}},
+		"events": nil,
+	})
+
+	assert.ErrorContains(t, err, `failed to create indexes on MongoDB collection "users"`)
+	assert.ErrorContains(t, err, `failed to create MongoDB collection "events"`)
+}
+
+func TestIsNamespaceExists(t *testing.T) {
+	assert.True(t, isNamespaceExists(mongo.CommandError{Code: 48, Name: "NamespaceExists"}))
+	assert.False(t, isNamespaceExists(mongo.CommandError{Code: 85, Name: "IndexOptionsConflict"}))
+	assert.False(t, isNamespaceExists(errors.New("connection refused")))
+}
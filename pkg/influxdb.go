@@ -0,0 +1,109 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// InfluxDB is an InfluxDB 2.x client bound to the organization and bucket it was connected with.
+type InfluxDB struct {
+	Client influxdb2.Client
+	Org    string
+	Bucket string
+}
+
+// WriteAPI returns the asynchronous, batching write API for the bucket.
+func (db *InfluxDB) WriteAPI() api.WriteAPI {
+	return db.Client.WriteAPI(db.Org, db.Bucket)
+}
+
+// WriteAPIBlocking returns the synchronous write API for the bucket.
+func (db *InfluxDB) WriteAPIBlocking() api.WriteAPIBlocking {
+	return db.Client.WriteAPIBlocking(db.Org, db.Bucket)
+}
+
+// QueryAPI returns the query API for the organization.
+func (db *InfluxDB) QueryAPI() api.QueryAPI {
+	return db.Client.QueryAPI(db.Org)
+}
+
+// Close flushes pending writes and closes the client.
+func (db *InfluxDB) Close() {
+	db.Client.Close()
+}
+
+// NewInfluxDBConnection creates an InfluxDB 2.x client for serverURL authenticated with token,
+// and checks the server's health before returning it bound to org and bucket.
+// If any error occurs, it logs the error and terminates the application.
+func NewInfluxDBConnection(serverURL, token, org, bucket string, opts ...Option) *InfluxDB {
+	db, err := NewInfluxDBConnectionCtx(context.Background(), serverURL, token, org, bucket, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to InfluxDB: %v", err.Error())
+	}
+
+	return db
+}
+
+// NewInfluxDBConnectionCtx creates an InfluxDB 2.x client like NewInfluxDBConnection,
+// but bounds the health check with ctx and returns an error instead of terminating the application.
+func NewInfluxDBConnectionCtx(ctx context.Context, serverURL, token, org, bucket string, opts ...Option) (*InfluxDB, error) {
+	if org == "" || bucket == "" {
+		return nil, errors.New("InfluxDB organization and bucket must not be empty")
+	}
+
+	return connectInfluxDB(ctx, serverURL, token, org, bucket, newOptions(opts))
+}
+
+// connectInfluxDB creates the client and checks its health according to the retry policy in o.
+// The client is closed if the check fails.
+func connectInfluxDB(ctx context.Context, serverURL, token, org, bucket string, o *Options) (_ *InfluxDB, err error) {
+	host := dsnHost(serverURL)
+	ctx, cancel := o.connectContext(ctx)
+	defer cancel()
+
+	ctx, span := o.startConnectSpan(ctx, "influxdb", host)
+	defer func() {
+		err = redactErr(err, serverURL, token)
+		endSpan(span, err)
+		o.finishConnect("influxdb", host, err)
+	}()
+
+	o.Logger.Infof("Connecting to InfluxDB at %s", redactDSN(serverURL))
+
+	clientOpts := influxdb2.DefaultOptions()
+	if o.HTTPClient != nil {
+		clientOpts.SetHTTPClient(o.HTTPClient)
+	}
+	client := influxdb2.NewClientWithOptions(serverURL, token, clientOpts)
+
+	err = o.ping(ctx, func(ctx context.Context) error {
+		health, err := client.Health(ctx)
+		if err != nil {
+			return err
+		}
+		if health.Status != domain.HealthCheckStatusPass {
+			message := ""
+			if health.Message != nil {
+				message = *health.Message
+			}
+			return fmt.Errorf("server reported status %q: %s", health.Status, message)
+		}
+		return nil
+	})
+	if err != nil {
+		client.Close()
+		return nil, connectionError("influxdb", StagePing, fmt.Errorf("InfluxDB health check failed: %w", err))
+	}
+
+	o.Logger.Infof("Successfully connected to InfluxDB")
+
+	db := &InfluxDB{Client: client, Org: org, Bucket: bucket}
+	o.track(db)
+
+	return db, nil
+}
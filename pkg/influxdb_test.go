@@ -0,0 +1,58 @@
+package pkg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInfluxDBConnection(t *testing.T) {
+	db := NewInfluxDBConnection("http://localhost:8086", "my-token", "my-org", "my-bucket")
+	defer db.Close()
+
+	ok, err := db.Client.Ping(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, ok, "Expected InfluxDB to answer the ping")
+}
+
+func newInfluxHealthServer(t *testing.T, status string) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if status != "pass" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_, _ = w.Write([]byte(`{"name":"influxdb","message":"ready for queries and writes","status":"` + status + `","checks":[]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func TestNewInfluxDBConnectionCtxHealthy(t *testing.T) {
+	db, err := NewInfluxDBConnectionCtx(context.Background(), newInfluxHealthServer(t, "pass"), "token", "org", "bucket")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "org", db.Org)
+	assert.Equal(t, "bucket", db.Bucket)
+	db.Close()
+}
+
+func TestNewInfluxDBConnectionCtxUnhealthy(t *testing.T) {
+	db, err := NewInfluxDBConnectionCtx(context.Background(), newInfluxHealthServer(t, "fail"), "s3cret-token", "org", "bucket")
+
+	assert.Nil(t, db)
+	assert.ErrorContains(t, err, "InfluxDB health check failed")
+	assert.NotContains(t, err.Error(), "s3cret-token")
+}
+
+func TestNewInfluxDBConnectionCtxRequiresOrgAndBucket(t *testing.T) {
+	db, err := NewInfluxDBConnectionCtx(context.Background(), "http://localhost:8086", "token", "org", "")
+
+	assert.Nil(t, db)
+	assert.Error(t, err)
+}
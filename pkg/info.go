@@ -0,0 +1,301 @@
+package pkg
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/x/mongo/driver/connstring"
+)
+
+// ConnectionInfo describes an established connection for startup diagnostics, such as a "connected" log event
+// with the same fields for every backend. It is returned by the *WithInfo variants of the connectors.
+// Only the MySQL, PostgreSQL, MongoDB and Redis connectors and ConnectWithInfo have such variants;
+// CockroachDB, MariaDB, TiDB, SQL Server and the other backends do not report a ConnectionInfo.
+type ConnectionInfo struct {
+	// Driver is the backend, as in Connection.Kind.
+	Driver Kind
+	// Address is the server as host:port, the comma-separated hosts of a MongoDB deployment, or the SQLite file.
+	Address string
+	// Database is the database name, or the numeric database for Redis.
+	Database string
+	// ConnectedAt is when the connection was confirmed.
+	ConnectedAt time.Time
+	// ServerVersion is the version reported by the server. It is empty if the server did not tell.
+	ServerVersion string
+	// Latency is the round trip time of a ping right after connecting.
+	Latency time.Duration
+}
+
+// String formats the info for log messages, e.g. "postgres 16.4 at db.local:5432/orders (ping 1.2ms)".
+func (i ConnectionInfo) String() string {
+	var b strings.Builder
+	b.WriteString(string(i.Driver))
+	if i.ServerVersion != "" {
+		b.WriteString(" " + i.ServerVersion)
+	}
+	b.WriteString(" at " + i.Address)
+	if i.Database != "" {
+		b.WriteString("/" + i.Database)
+	}
+	fmt.Fprintf(&b, " (ping %v)", i.Latency)
+	return b.String()
+}
+
+// measureConnection pings the connection to time the round trip and queries the server version with version.
+// A failing version query leaves ServerVersion empty, since it may need privileges the connection lacks.
+func measureConnection(ctx context.Context, info ConnectionInfo, ping func(context.Context) error, version func(context.Context) (string, error)) (ConnectionInfo, error) {
+	start := time.Now()
+	if err := ping(ctx); err != nil {
+		return info, connectionError(string(info.Driver), StagePing, fmt.Errorf("failed to measure ping: %w", err))
+	}
+	info.Latency = time.Since(start)
+	info.ConnectedAt = time.Now()
+	info.ServerVersion, _ = version(ctx)
+
+	return info, nil
+}
+
+// sqlVersionQueries holds the query returning the server version, per SQL backend.
+var sqlVersionQueries = map[Kind]string{
+	KindMySQL:    "SELECT VERSION()",
+	KindPostgres: "SHOW server_version",
+	KindSQLite:   "SELECT sqlite_version()",
+}
+
+// sqlConnectionInfo measures db, a connection to a kind database.
+func sqlConnectionInfo(ctx context.Context, kind Kind, address, database string, db *sql.DB) (ConnectionInfo, error) {
+	return measureConnection(ctx, ConnectionInfo{Driver: kind, Address: address, Database: database}, db.PingContext, func(ctx context.Context) (string, error) {
+		return sqlVersion(ctx, db, sqlVersionQueries[kind])
+	})
+}
+
+// mongoConnectionInfo measures client, a connection to the MongoDB deployment at address.
+func mongoConnectionInfo(ctx context.Context, address, database string, client *mongo.Client) (ConnectionInfo, error) {
+	ping := func(ctx context.Context) error {
+		return client.Ping(ctx, nil)
+	}
+	return measureConnection(ctx, ConnectionInfo{Driver: KindMongoDB, Address: address, Database: database}, ping, func(ctx context.Context) (string, error) {
+		return MongoVersion(ctx, client)
+	})
+}
+
+// redisConnectionInfo measures client, a connection to the Redis server at address.
+func redisConnectionInfo(ctx context.Context, address string, db int, client *redis.Client) (ConnectionInfo, error) {
+	ping := func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	}
+	return measureConnection(ctx, ConnectionInfo{Driver: KindRedis, Address: address, Database: strconv.Itoa(db)}, ping, func(ctx context.Context) (string, error) {
+		return RedisVersion(ctx, client)
+	})
+}
+
+// NewSQLDBConnectionWithInfo establishes a connection to a MySQL database like NewSQLDBConnectionCtx
+// and returns it together with its ConnectionInfo.
+func NewSQLDBConnectionWithInfo[T string | mysql.Config](ctx context.Context, cfg T, opts ...Option) (*sql.DB, ConnectionInfo, error) {
+	var mysqlCfg *mysql.Config
+	switch v := any(cfg).(type) {
+	case string:
+		parsed, err := mysql.ParseDSN(v)
+		if err != nil {
+			return nil, ConnectionInfo{}, redactErr(connectionError("mysql", StageConfig, fmt.Errorf("invalid MySQL DSN: %w", err)), v)
+		}
+		mysqlCfg = parsed
+	case mysql.Config:
+		mysqlCfg = &v
+	}
+
+	var info ConnectionInfo
+	db, err := NewSQLDBConnectionCtx(ctx, cfg, withInspect(opts, &info, func(conn any) (ConnectionInfo, error) {
+		return sqlConnectionInfo(ctx, KindMySQL, mysqlCfg.Addr, mysqlCfg.DBName, conn.(*sql.DB))
+	})...)
+	if err != nil {
+		return nil, ConnectionInfo{}, err
+	}
+	return db, info, nil
+}
+
+// NewPostgresDBConnectionWithInfo establishes a connection to a PostgreSQL database like NewPostgresDBConnectionE,
+// bounding the ping for the info with ctx, and returns it together with its ConnectionInfo.
+func NewPostgresDBConnectionWithInfo[T string | PostgresConfig](ctx context.Context, cfg T, opts ...Option) (*sql.DB, ConnectionInfo, error) {
+	dsn, ok := any(cfg).(string)
+	if !ok {
+		dsn = any(cfg).(PostgresConfig).BuildDSN()
+	}
+	address, database, err := pqTarget(dsn)
+	if err != nil {
+		return nil, ConnectionInfo{}, redactErr(connectionError("postgresql", StageConfig, fmt.Errorf("invalid PostgreSQL connection string: %w", err)), dsn)
+	}
+
+	var info ConnectionInfo
+	db, err := NewPostgresDBConnectionE(dsn, withInspect(opts, &info, func(conn any) (ConnectionInfo, error) {
+		return sqlConnectionInfo(ctx, KindPostgres, address, database, conn.(*sql.DB))
+	})...)
+	if err != nil {
+		return nil, ConnectionInfo{}, err
+	}
+	return db, info, nil
+}
+
+// pqTarget returns the host:port and database that the lib/pq driver dials for dsn, a URL or a key=value
+// connection string. Like the driver, it defaults to localhost:5432 and takes PGHOST, PGPORT and PGDATABASE
+// from the environment for settings the DSN leaves out.
+func pqTarget(dsn string) (address, database string, err error) {
+	if _, err := pq.NewConnector(dsn); err != nil {
+		return "", "", err
+	}
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		if dsn, err = pq.ParseURL(dsn); err != nil {
+			return "", "", err
+		}
+	}
+
+	settings := map[string]string{
+		"host":   cmp.Or(os.Getenv("PGHOST"), "localhost"),
+		"port":   cmp.Or(os.Getenv("PGPORT"), "5432"),
+		"dbname": os.Getenv("PGDATABASE"),
+	}
+	for key, value := range pqSettings(dsn) {
+		settings[key] = value
+	}
+
+	return net.JoinHostPort(settings["host"], settings["port"]), settings["dbname"], nil
+}
+
+// pqSettings splits a key=value connection string that lib/pq has accepted into its settings,
+// unquoting single-quoted values and backslash escapes the way the driver does.
+func pqSettings(dsn string) map[string]string {
+	settings := map[string]string{}
+	runes := []rune(dsn)
+	i := 0
+	skipSpaces := func() {
+		for i < len(runes) && unicode.IsSpace(runes[i]) {
+			i++
+		}
+	}
+
+	for {
+		skipSpaces()
+		if i >= len(runes) {
+			return settings
+		}
+
+		start := i
+		for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '=' {
+			i++
+		}
+		key := string(runes[start:i])
+		skipSpaces()
+		i++ // the "="
+		skipSpaces()
+
+		var value []rune
+		if i < len(runes) && runes[i] == '\'' {
+			for i++; i < len(runes) && runes[i] != '\''; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				value = append(value, runes[i])
+			}
+			i++ // the closing quote
+		} else {
+			for ; i < len(runes) && !unicode.IsSpace(runes[i]); i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				value = append(value, runes[i])
+			}
+		}
+		settings[key] = string(value)
+	}
+}
+
+// NewMongoDBConnectionWithInfo establishes a connection to a MongoDB server like NewMongoDBConnectionCtx
+// and returns the client together with its ConnectionInfo.
+func NewMongoDBConnectionWithInfo(ctx context.Context, connectionURI string, opts ...Option) (*mongo.Client, ConnectionInfo, error) {
+	// Invalid URIs are reported by the connector, so only custom schemes from WithMongoSchemes need rewriting here.
+	_, rest, _ := strings.Cut(connectionURI, "://")
+	parsed, err := connstring.Parse(connstring.SchemeMongoDB + "://" + rest)
+	if err != nil {
+		parsed = &connstring.ConnString{}
+	}
+
+	var info ConnectionInfo
+	client, err := NewMongoDBConnectionCtx(ctx, connectionURI, withInspect(opts, &info, func(conn any) (ConnectionInfo, error) {
+		return mongoConnectionInfo(ctx, strings.Join(parsed.Hosts, ","), parsed.Database, conn.(*mongo.Client))
+	})...)
+	if err != nil {
+		return nil, ConnectionInfo{}, err
+	}
+	return client, info, nil
+}
+
+// NewRedisConnectionWithInfo establishes a connection to a Redis server like NewRedisConnectionE,
+// bounding the ping for the info with ctx, and returns the client together with its ConnectionInfo.
+func NewRedisConnectionWithInfo[T string | *redis.Options | RedisConfig](ctx context.Context, cfg T, opts ...Option) (*redis.Client, ConnectionInfo, error) {
+	var info ConnectionInfo
+	client, err := NewRedisConnectionE(cfg, withInspect(opts, &info, func(conn any) (ConnectionInfo, error) {
+		client := conn.(*redis.Client)
+		return redisConnectionInfo(ctx, client.Options().Addr, client.Options().DB, client)
+	})...)
+	if err != nil {
+		return nil, ConnectionInfo{}, err
+	}
+	return client, info, nil
+}
+
+// ConnectWithInfo connects to uri like Connect and returns the connection together with its ConnectionInfo,
+// which covers every scheme Connect supports.
+func ConnectWithInfo(ctx context.Context, uri string, opts ...Option) (*Connection, ConnectionInfo, error) {
+	parsed, err := ParseURI(uri)
+	if err != nil {
+		return nil, ConnectionInfo{}, err
+	}
+
+	address := strings.Join(parsed.Hosts, ",")
+	database := parsed.Database
+	if parsed.Driver == KindSQLite {
+		address, database = parsed.Database, ""
+	}
+
+	var info ConnectionInfo
+	conn, err := Connect(uri, withInspect(opts, &info, func(conn any) (ConnectionInfo, error) {
+		switch client := conn.(type) {
+		case *sql.DB:
+			return sqlConnectionInfo(ctx, parsed.Driver, address, database, client)
+		case *mongo.Client:
+			return mongoConnectionInfo(ctx, address, database, client)
+		case *redis.Client:
+			return redisConnectionInfo(ctx, address, client.Options().DB, client)
+		default:
+			return ConnectionInfo{}, fmt.Errorf("cannot describe connection of type %T", conn)
+		}
+	})...)
+	if err != nil {
+		return nil, ConnectionInfo{}, err
+	}
+	return conn, info, nil
+}
+
+// withInspect returns opts with an option that stores the info built by measure in info. The measurement runs
+// before the connector tracks or registers the handle, so a failure leaves no closed handle behind.
+func withInspect(opts []Option, info *ConnectionInfo, measure func(conn any) (ConnectionInfo, error)) []Option {
+	return append(slices.Clip(opts), func(o *Options) {
+		o.inspect = func(conn any) (err error) {
+			*info, err = measure(conn)
+			return err
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+)
+
+// SQLDB is the subset of *sql.DB that application code typically needs.
+// Accept it instead of *sql.DB to substitute the fake from the mock package in tests.
+type SQLDB interface {
+	PingContext(ctx context.Context) error
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+	Close() error
+}
+
+// RedisClient is the subset of the Redis clients used for key/value access.
+// *redis.Client, *redis.ClusterClient and the failover clients satisfy it.
+type RedisClient interface {
+	Ping(ctx context.Context) *redis.StatusCmd
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+	Close() error
+}
+
+// MongoClient is the subset of *mongo.Client needed to reach databases and manage the connection.
+type MongoClient interface {
+	Ping(ctx context.Context, rp *readpref.ReadPref) error
+	Database(name string, opts ...options.Lister[options.DatabaseOptions]) *mongo.Database
+	Disconnect(ctx context.Context) error
+}
+
+var (
+	_ SQLDB       = (*sql.DB)(nil)
+	_ RedisClient = (*redis.Client)(nil)
+	_ RedisClient = (*redis.ClusterClient)(nil)
+	_ MongoClient = (*mongo.Client)(nil)
+)
@@ -0,0 +1,46 @@
+package pkg
+
+import (
+	"context"
+	"time"
+)
+
+// defaultKeepAliveInterval is the time between keepalive pings when StartKeepAlive is given no positive interval.
+const defaultKeepAliveInterval = 30 * time.Second
+
+// StartKeepAlive pings db every interval in a new goroutine until ctx is done, which keeps an idle connection
+// from being dropped by firewalls and load balancers and reveals dead servers before the next query does.
+// Failed pings are logged as warnings and the loop keeps running; each ping is bounded by interval,
+// which defaults to 30s if it is not positive.
+// Only one pooled connection is exercised per ping, so combine it with PoolConfig.MaxIdleTime for larger pools.
+// The returned channel is closed once the goroutine has exited.
+func StartKeepAlive(ctx context.Context, db SQLDB, interval time.Duration, opts ...Option) <-chan struct{} {
+	o := newOptions(opts)
+	if interval <= 0 {
+		interval = defaultKeepAliveInterval
+	}
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				pingCtx, cancel := context.WithTimeout(ctx, interval)
+				err := db.PingContext(pingCtx)
+				cancel()
+				if err != nil && ctx.Err() == nil {
+					o.Logger.Warnf("Keepalive ping failed: %v", err)
+				}
+			}
+		}
+	}()
+
+	return done
+}
@@ -0,0 +1,97 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pingFunc is a SQLDB whose PingContext calls the function; only the keepalive uses it.
+type pingFunc struct {
+	SQLDB
+	ping func(ctx context.Context) error
+}
+
+func (p pingFunc) PingContext(ctx context.Context) error {
+	return p.ping(ctx)
+}
+
+func TestStartKeepAlivePingsUntilCancelled(t *testing.T) {
+	var pings atomic.Int32
+	db := pingFunc{ping: func(context.Context) error {
+		pings.Add(1)
+		return nil
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := StartKeepAlive(ctx, db, 10*time.Millisecond)
+
+	assert.Eventually(t, func() bool { return pings.Load() >= 3 }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the keepalive to exit after the context was cancelled")
+	}
+
+	stopped := pings.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, pings.Load(), "Expected no pings after exit")
+}
+
+// syncBuffer is a bytes.Buffer that is safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestStartKeepAliveLogsFailures(t *testing.T) {
+	var buf syncBuffer
+	logger := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	db := pingFunc{ping: func(context.Context) error { return errors.New("connection reset by peer") }}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := StartKeepAlive(ctx, db, 10*time.Millisecond, WithLogger(logger))
+
+	assert.Eventually(t, func() bool {
+		return strings.Contains(buf.String(), "Keepalive ping failed: connection reset by peer")
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+}
+
+func TestStartKeepAliveDefaultsNonPositiveInterval(t *testing.T) {
+	db := pingFunc{ping: func(context.Context) error { return nil }}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := StartKeepAlive(ctx, db, 0)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the keepalive goroutine to exit after cancellation")
+	}
+}
@@ -0,0 +1,86 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Logger is the logging interface used by the connectors.
+// The package-level logrus logger satisfies it and is used when no Logger is configured.
+type Logger interface {
+	Infof(format string, args ...any)
+	Warnf(format string, args ...any)
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+}
+
+// WithLogger routes the connector's log output through l. A nil l keeps the default logrus logger.
+func WithLogger(l Logger) Option {
+	return func(o *Options) {
+		o.Logger = l
+	}
+}
+
+// FailureMode selects how the connectors without an error return react to a failed connection.
+type FailureMode int
+
+const (
+	// FailFatal logs the failure with the logger's Fatalf, which exits the process. This is the default.
+	FailFatal FailureMode = iota
+	// FailPanic logs the failure at error level and panics with an error carrying the message,
+	// so that tests and plugin hosts can recover from it.
+	FailPanic
+)
+
+// FailMode is the FailureMode used by the connectors without an error return, such as NewRedisConnection.
+// Set it once during initialisation; it is not safe to change while connections are being opened.
+var FailMode = FailFatal
+
+// fatalf reports an unrecoverable connector failure through log according to FailMode.
+func fatalf(log Logger, format string, args ...any) {
+	if FailMode == FailPanic {
+		msg := fmt.Sprintf(format, args...)
+		log.Errorf("%s", msg)
+		panic(errors.New(msg))
+	}
+	log.Fatalf(format, args...)
+}
+
+// defaultLogger returns the logger used when none is configured.
+func defaultLogger() Logger {
+	return logrus.StandardLogger()
+}
+
+// NewSlogLogger adapts l to the Logger interface. A nil l uses slog.Default().
+// Fatalf logs at error level and then exits the process with status 1.
+func NewSlogLogger(l *slog.Logger) Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return slogLogger{l: l}
+}
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+func (s slogLogger) Infof(format string, args ...any) {
+	s.l.Info(fmt.Sprintf(format, args...))
+}
+
+func (s slogLogger) Warnf(format string, args ...any) {
+	s.l.Warn(fmt.Sprintf(format, args...))
+}
+
+func (s slogLogger) Errorf(format string, args ...any) {
+	s.l.Error(fmt.Sprintf(format, args...))
+}
+
+func (s slogLogger) Fatalf(format string, args ...any) {
+	s.l.Error(fmt.Sprintf(format, args...))
+	os.Exit(1)
+}
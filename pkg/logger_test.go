@@ -0,0 +1,44 @@
+package pkg
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlogLoggerLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	logger.Infof("connected to %s", "redis")
+	logger.Warnf("retrying %d", 2)
+
+	assert.Contains(t, buf.String(), `level=INFO msg="connected to redis"`)
+	assert.Contains(t, buf.String(), `level=WARN msg="retrying 2"`)
+}
+
+func TestWithLoggerDefaultsToLogrus(t *testing.T) {
+	o := newOptions([]Option{WithLogger(nil)})
+
+	assert.Equal(t, defaultLogger(), o.Logger)
+}
+
+func TestFailModePanic(t *testing.T) {
+	FailMode = FailPanic
+	defer func() { FailMode = FailFatal }()
+
+	assert.PanicsWithError(t, "Failed to connect to MongoDB: no reachable servers", func() {
+		fatalf(defaultLogger(), "Failed to connect to MongoDB: %v", "no reachable servers")
+	})
+}
+
+func TestFailModePanicRecoversFromConnector(t *testing.T) {
+	FailMode = FailPanic
+	defer func() { FailMode = FailFatal }()
+
+	assert.Panics(t, func() {
+		NewSQLiteConnection("", "")
+	})
+}
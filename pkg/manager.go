@@ -0,0 +1,156 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+)
+
+// defaultHealthCheckTimeout bounds each check of Manager.HealthCheckAll when Manager.HealthTimeout is not set.
+const defaultHealthCheckTimeout = 5 * time.Second
+
+// Manager holds the named connections of an application, checks their health together and closes them on
+// shutdown. Supported are the handles returned by the connectors that can be pinged: *sql.DB, the Redis clients,
+// *mongo.Client, *SupervisedMongoClient, *gocql.Session and any value with a Ping(ctx) error method, such as
+// *pgxpool.Pool. The zero value is ready to use and safe for concurrent use.
+type Manager struct {
+	// HealthTimeout bounds the check of each connection in HealthCheckAll. Defaults to 5s.
+	HealthTimeout time.Duration
+
+	mu    sync.RWMutex
+	conns map[string]managedConn
+	names []string
+}
+
+// managedConn is a connection held by a Manager, with the functions checking and closing it.
+type managedConn struct {
+	conn  any
+	check func(context.Context) error
+	close func(context.Context) error
+}
+
+// Add holds conn under name. It fails if the name is taken or conn is not a supported handle.
+func (m *Manager) Add(name string, conn any) error {
+	check, err := healthCheckFor(conn)
+	if err != nil {
+		return err
+	}
+	closeFn, err := closerFor(conn)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.conns[name]; ok {
+		return fmt.Errorf("connection %q is already added", name)
+	}
+	if m.conns == nil {
+		m.conns = map[string]managedConn{}
+	}
+	m.conns[name] = managedConn{conn: conn, check: check, close: closeFn}
+	m.names = append(m.names, name)
+
+	return nil
+}
+
+// Get returns the connection added under name, and whether there is one.
+func (m *Manager) Get(name string) (any, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	c, ok := m.conns[name]
+	return c.conn, ok
+}
+
+// HealthCheckAll pings every connection concurrently, each bounded by HealthTimeout within ctx, and returns
+// the result per name: nil for a healthy connection, the error of the ping otherwise. It neither logs nor exits,
+// which makes it suitable for readiness probes.
+func (m *Manager) HealthCheckAll(ctx context.Context) map[string]error {
+	timeout := m.HealthTimeout
+	if timeout <= 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+
+	m.mu.RLock()
+	conns := make(map[string]managedConn, len(m.conns))
+	for name, c := range m.conns {
+		conns[name] = c
+	}
+	m.mu.RUnlock()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]error, len(conns))
+	)
+	for name, c := range conns {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			err := c.check(checkCtx)
+
+			mu.Lock()
+			results[name] = err
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// Close closes every connection in the reverse order they were added and forgets them, so the names can be
+// added again. The context bounds the handles that accept one, such as the MongoDB client. All connections
+// are closed even if some fail; the failures are joined into the returned error.
+func (m *Manager) Close(ctx context.Context) error {
+	m.mu.Lock()
+	conns, names := m.conns, m.names
+	m.conns, m.names = nil, nil
+	m.mu.Unlock()
+
+	var errs []error
+	for i := len(names) - 1; i >= 0; i-- {
+		if err := conns[names[i]].close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", names[i], err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// healthCheckFor returns a function that pings conn, for the handle types supported by Manager.
+func healthCheckFor(conn any) (func(context.Context) error, error) {
+	switch v := conn.(type) {
+	case interface{ PingContext(context.Context) error }:
+		return v.PingContext, nil
+	case interface {
+		Ping(context.Context) *redis.StatusCmd
+	}:
+		return func(ctx context.Context) error { return v.Ping(ctx).Err() }, nil
+	case interface {
+		Ping(context.Context, *readpref.ReadPref) error
+	}:
+		return func(ctx context.Context) error { return v.Ping(ctx, nil) }, nil
+	case *SupervisedMongoClient:
+		return func(ctx context.Context) error { return v.Client().Ping(ctx, nil) }, nil
+	case *gocql.Session:
+		return func(ctx context.Context) error {
+			return v.Query("SELECT release_version FROM system.local").WithContext(ctx).Exec()
+		}, nil
+	case interface{ Ping(context.Context) error }:
+		return v.Ping, nil
+	default:
+		return nil, fmt.Errorf("cannot check the health of connection of type %T", conn)
+	}
+}
@@ -0,0 +1,86 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePinger is a connection with a Ping(ctx) error method that blocks for delay.
+type fakePinger struct {
+	delay  time.Duration
+	err    error
+	closed bool
+}
+
+func (f *fakePinger) Ping(ctx context.Context) error {
+	select {
+	case <-time.After(f.delay):
+		return f.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (f *fakePinger) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestManagerAddGet(t *testing.T) {
+	var m Manager
+	db := openTestSQLite(t)
+
+	require.NoError(t, m.Add("main", db))
+	assert.ErrorContains(t, m.Add("main", openTestSQLite(t)), `connection "main" is already added`)
+	assert.ErrorContains(t, m.Add("config", "not a connection"), "cannot check the health of connection of type string")
+
+	conn, ok := m.Get("main")
+	assert.True(t, ok)
+	assert.Same(t, db, conn)
+
+	_, ok = m.Get("missing")
+	assert.False(t, ok)
+}
+
+func TestManagerHealthCheckAll(t *testing.T) {
+	m := Manager{HealthTimeout: 100 * time.Millisecond}
+	unreachable := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	down := errors.New("down")
+
+	require.NoError(t, m.Add("main", openTestSQLite(t)))
+	require.NoError(t, m.Add("cache", unreachable))
+	require.NoError(t, m.Add("search", &fakePinger{err: down}))
+	require.NoError(t, m.Add("slow", &fakePinger{delay: time.Hour}))
+	defer m.Close(context.Background())
+
+	start := time.Now()
+	results := m.HealthCheckAll(context.Background())
+
+	assert.Less(t, time.Since(start), time.Second, "Expected the checks to run concurrently and be bounded")
+	assert.Len(t, results, 4)
+	assert.NoError(t, results["main"])
+	assert.Error(t, results["cache"])
+	assert.ErrorIs(t, results["search"], down)
+	assert.ErrorIs(t, results["slow"], context.DeadlineExceeded)
+}
+
+func TestManagerClose(t *testing.T) {
+	var m Manager
+	db := openTestSQLite(t)
+	search := &fakePinger{}
+
+	require.NoError(t, m.Add("main", db))
+	require.NoError(t, m.Add("search", search))
+
+	assert.NoError(t, m.Close(context.Background()))
+	assert.True(t, search.closed)
+	assert.ErrorContains(t, db.Ping(), "database is closed")
+	assert.Empty(t, m.HealthCheckAll(context.Background()))
+	assert.NoError(t, m.Add("main", openTestSQLite(t)), "Expected the name to be free after Close")
+}
@@ -0,0 +1,101 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	defaultMariaDBCollation = "utf8mb4_unicode_ci"
+	// defaultMariaDBSQLMode matches the default sql_mode of current MariaDB releases, minus NO_AUTO_CREATE_USER:
+	// MySQL 8.0 removed that mode and rejects it, which would fail the connection before WithMariaDBCheck can
+	// report the server is not MariaDB. It only affects GRANT, which applications do not run on connect.
+	defaultMariaDBSQLMode = "'STRICT_TRANS_TABLES,ERROR_FOR_DIVISION_BY_ZERO,NO_ENGINE_SUBSTITUTION'"
+)
+
+// WithMariaDBCheck makes NewMariaDBConnection verify that the server is MariaDB by checking @@version
+// after the ping, failing if it is a MySQL server instead.
+func WithMariaDBCheck() Option {
+	return func(o *Options) {
+		o.checkMariaDB = true
+	}
+}
+
+// NewMariaDBConnection establishes a connection to a MariaDB database through the MySQL driver.
+// It accepts either a connection string or a MySQL config object. Unless they are set already,
+// the collation defaults to utf8mb4_unicode_ci and sql_mode to the MariaDB default, so MariaDB-specific
+// behavior is consistent across servers. Pass WithMariaDBCheck to also verify the server is MariaDB.
+// If any error occurs, it logs the error and terminates the application.
+func NewMariaDBConnection[T string | mysql.Config](cfg T, opts ...Option) *sql.DB {
+	db, err := NewMariaDBConnectionE(cfg, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to the MariaDB database: %v", err.Error())
+	}
+
+	return db
+}
+
+// NewMariaDBConnectionE establishes a connection to a MariaDB database like NewMariaDBConnection,
+// but returns an error instead of terminating the application.
+func NewMariaDBConnectionE[T string | mysql.Config](cfg T, opts ...Option) (*sql.DB, error) {
+	o := newOptions(opts)
+
+	var mysqlCfg *mysql.Config
+
+	switch v := any(cfg).(type) {
+	case string:
+		parsed, err := mysql.ParseDSN(v)
+		if err != nil {
+			return nil, o.failed(redactErr(connectionError("mariadb", StageConfig, fmt.Errorf("invalid MariaDB DSN: %w", err)), v))
+		}
+		mysqlCfg = parsed
+	case mysql.Config:
+		mysqlCfg = v.Clone()
+		o.mysqlTimeouts.apply(mysqlCfg)
+		applyMySQLProgramName(mysqlCfg)
+	default:
+		return nil, fmt.Errorf("invalid config type: %T", v)
+	}
+
+	applyMariaDBDefaults(mysqlCfg)
+	if o.checkMariaDB {
+		o.verifySQL = checkMariaDBVersion
+	}
+
+	return openSQL(context.Background(), "MariaDB", "mariadb", "mysql", mysqlCfg.FormatDSN(), PoolConfig{}, o)
+}
+
+// applyMariaDBDefaults sets the MariaDB collation and sql_mode on cfg unless they are set already.
+func applyMariaDBDefaults(cfg *mysql.Config) {
+	if cfg.Collation == "" {
+		cfg.Collation = defaultMariaDBCollation
+	}
+	if _, ok := cfg.Params["sql_mode"]; !ok {
+		if cfg.Params == nil {
+			cfg.Params = map[string]string{}
+		}
+		cfg.Params["sql_mode"] = defaultMariaDBSQLMode
+	}
+}
+
+// checkMariaDBVersion returns an error if the server behind db does not report a MariaDB version.
+func checkMariaDBVersion(ctx context.Context, db *sql.DB) error {
+	var version string
+	if err := db.QueryRowContext(ctx, "SELECT @@version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to query server version: %w", err)
+	}
+
+	if !isMariaDBVersion(version) {
+		return fmt.Errorf("server version %q is not MariaDB", version)
+	}
+	return nil
+}
+
+// isMariaDBVersion reports whether a @@version string such as "11.4.2-MariaDB-ubu2404" belongs to MariaDB.
+func isMariaDBVersion(version string) bool {
+	return strings.Contains(strings.ToLower(version), "mariadb")
+}
@@ -0,0 +1,45 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMariaDBConnection(t *testing.T) {
+	db := NewMariaDBConnection("user:password@tcp(localhost:3306)/testdb", WithMariaDBCheck())
+	defer db.Close()
+
+	assert.NoError(t, db.Ping())
+}
+
+func TestApplyMariaDBDefaults(t *testing.T) {
+	cfg := mysql.NewConfig()
+	applyMariaDBDefaults(cfg)
+
+	assert.Equal(t, defaultMariaDBCollation, cfg.Collation)
+	assert.Equal(t, defaultMariaDBSQLMode, cfg.Params["sql_mode"])
+	assert.NotContains(t, cfg.Params["sql_mode"], "NO_AUTO_CREATE_USER", "Expected a sql_mode that MySQL 8.0 accepts")
+
+	custom := mysql.NewConfig()
+	custom.Collation = "utf8mb4_general_ci"
+	custom.Params = map[string]string{"sql_mode": "'ANSI'"}
+	applyMariaDBDefaults(custom)
+
+	assert.Equal(t, "utf8mb4_general_ci", custom.Collation)
+	assert.Equal(t, "'ANSI'", custom.Params["sql_mode"])
+}
+
+func TestIsMariaDBVersion(t *testing.T) {
+	assert.True(t, isMariaDBVersion("11.4.2-MariaDB-ubu2404"))
+	assert.True(t, isMariaDBVersion("5.5.5-10.11.6-MariaDB"))
+	assert.False(t, isMariaDBVersion("8.0.36"))
+}
+
+func TestNewMariaDBConnectionEMalformedDSN(t *testing.T) {
+	db, err := NewMariaDBConnectionE("user:password@tcp(localhost:3306)testdb")
+
+	assert.Nil(t, db)
+	assert.ErrorContains(t, err, "invalid MariaDB DSN")
+}
@@ -0,0 +1,32 @@
+package pkg
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLStatsCollector(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(3)
+
+	registry := prometheus.NewRegistry()
+	assert.NoError(t, registry.Register(NewSQLStatsCollector(db, "sqlite")))
+	assert.Error(t, registry.Register(NewSQLStatsCollector(db, "sqlite")), "Expected duplicate names to be rejected")
+	assert.NoError(t, registry.Register(NewSQLStatsCollector(db, "other")))
+
+	expected := `
+# HELP go_sql_max_open_connections Maximum number of open connections to the database.
+# TYPE go_sql_max_open_connections gauge
+go_sql_max_open_connections{db_name="other"} 3
+go_sql_max_open_connections{db_name="sqlite"} 3
+`
+	err = testutil.GatherAndCompare(registry, strings.NewReader(expected), "go_sql_max_open_connections")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,97 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/pressly/goose/v3"
+)
+
+// WithMigrationDryRun makes RunMigrations report the pending migrations without applying them.
+func WithMigrationDryRun() Option {
+	return func(o *Options) {
+		o.migrationDryRun = true
+	}
+}
+
+// migrationDialects maps database/sql driver names and the names used by this package to goose dialects.
+var migrationDialects = map[string]goose.Dialect{
+	"postgres":   goose.DialectPostgres,
+	"postgresql": goose.DialectPostgres,
+	"pgx":        goose.DialectPostgres,
+	"mysql":      goose.DialectMySQL,
+	"mariadb":    goose.DialectMySQL,
+	"tidb":       goose.DialectTiDB,
+	"sqlite":     goose.DialectSQLite3,
+	"sqlite3":    goose.DialectSQLite3,
+	"sqlserver":  goose.DialectMSSQL,
+	"mssql":      goose.DialectMSSQL,
+	"clickhouse": goose.DialectClickHouse,
+}
+
+// RunMigrations applies the pending goose migrations in directory dir of fsys, typically an embed.FS, to db
+// and returns the versions it applied, logging each of them. The driver names the database, e.g. "postgres",
+// "mysql", "sqlite3" or "sqlserver". Applied versions are recorded in the goose_db_version table.
+// With WithMigrationDryRun the pending versions are returned and logged instead, and nothing is applied.
+func RunMigrations(db *sql.DB, driver string, fsys fs.FS, dir string, opts ...Option) ([]int64, error) {
+	o := newOptions(opts)
+
+	dialect, ok := migrationDialects[driver]
+	if !ok {
+		return nil, fmt.Errorf("unsupported migration driver %q", driver)
+	}
+
+	migrations, err := fs.Sub(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid migration directory %q: %w", dir, err)
+	}
+
+	// The provider is not closed, as that would close db.
+	provider, err := goose.NewProvider(dialect, db, migrations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	ctx := context.Background()
+
+	if o.migrationDryRun {
+		statuses, err := provider.Status(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration status: %w", err)
+		}
+
+		var pending []int64
+		for _, status := range statuses {
+			if status.State == goose.StatePending {
+				o.Logger.Infof("Pending migration %d (%s)", status.Source.Version, status.Source.Path)
+				pending = append(pending, status.Source.Version)
+			}
+		}
+		return pending, nil
+	}
+
+	results, err := provider.Up(ctx)
+	var partial *goose.PartialError
+	if errors.As(err, &partial) {
+		results = partial.Applied
+	}
+
+	applied := make([]int64, 0, len(results))
+	for _, result := range results {
+		if result.Error == nil {
+			o.Logger.Infof("Applied migration %d (%s) in %v", result.Source.Version, result.Source.Path, result.Duration)
+			applied = append(applied, result.Source.Version)
+		}
+	}
+	if err != nil {
+		return applied, fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	if len(applied) == 0 {
+		o.Logger.Infof("No pending migrations")
+	}
+	return applied, nil
+}
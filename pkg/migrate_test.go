@@ -0,0 +1,69 @@
+package pkg
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testMigrations = fstest.MapFS{
+	"migrations/00001_create_users.sql": {Data: []byte(`-- +goose Up
+CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL);
+
+-- +goose Down
+DROP TABLE users;
+`)},
+	"migrations/00002_add_email.sql": {Data: []byte(`-- +goose Up
+ALTER TABLE users ADD COLUMN email TEXT;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN email;
+`)},
+}
+
+func TestRunMigrations(t *testing.T) {
+	db := openTestSQLite(t)
+
+	applied, err := RunMigrations(db, "sqlite3", testMigrations, "migrations")
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, applied)
+
+	_, err = db.Exec("INSERT INTO users (name, email) VALUES ('ada', 'ada@example.com')")
+	assert.NoError(t, err)
+
+	applied, err = RunMigrations(db, "sqlite3", testMigrations, "migrations")
+	assert.NoError(t, err)
+	assert.Empty(t, applied, "Expected no migrations to be pending")
+	assert.NoError(t, db.Ping(), "Expected the database to stay open")
+}
+
+func TestRunMigrationsDryRun(t *testing.T) {
+	db := openTestSQLite(t)
+
+	pending, err := RunMigrations(db, "sqlite3", testMigrations, "migrations", WithMigrationDryRun())
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, pending)
+
+	var tables int
+	assert.NoError(t, db.QueryRow("SELECT count(*) FROM sqlite_master WHERE name = 'users'").Scan(&tables))
+	assert.Zero(t, tables, "Expected the dry run not to apply migrations")
+}
+
+func TestRunMigrationsReportsFailures(t *testing.T) {
+	db := openTestSQLite(t)
+	broken := fstest.MapFS{
+		"migrations/00001_create_users.sql": testMigrations["migrations/00001_create_users.sql"],
+		"migrations/00002_broken.sql":       {Data: []byte("-- +goose Up\nCREATE TABLEX nope;\n")},
+	}
+
+	applied, err := RunMigrations(db, "sqlite3", broken, "migrations")
+	assert.ErrorContains(t, err, "failed to apply migrations")
+	assert.Equal(t, []int64{1}, applied)
+}
+
+func TestRunMigrationsUnsupportedDriver(t *testing.T) {
+	_, err := RunMigrations(openTestSQLite(t), "cassandra", testMigrations, "migrations")
+
+	assert.EqualError(t, err, `unsupported migration driver "cassandra"`)
+}
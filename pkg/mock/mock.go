@@ -0,0 +1,247 @@
+// Package mock provides fakes for the interfaces in the pkg package, for unit tests of code that
+// accepts pkg.SQLDB, pkg.RedisClient or pkg.MongoClient instead of the concrete driver types.
+package mock
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cherry-blossom1/go-database-connection/pkg"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+)
+
+var (
+	_ pkg.SQLDB       = (*SQLDB)(nil)
+	_ pkg.RedisClient = (*Redis)(nil)
+	_ pkg.MongoClient = (*MongoClient)(nil)
+)
+
+// SQLDB is a pkg.SQLDB whose methods call the corresponding function fields.
+// Unset PingContextFunc, ExecContextFunc and CloseFunc make the method succeed without doing anything.
+// Unset QueryContextFunc and BeginTxFunc make the method return an error, since there are no rows or
+// transaction to return. An unset QueryRowContextFunc makes QueryRowContext return a nil *sql.Row, whose
+// Scan panics, as database/sql offers no way to build a row holding an error; set it for code that
+// queries single rows.
+type SQLDB struct {
+	PingContextFunc     func(ctx context.Context) error
+	ExecContextFunc     func(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContextFunc    func(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContextFunc func(ctx context.Context, query string, args ...any) *sql.Row
+	BeginTxFunc         func(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+	CloseFunc           func() error
+}
+
+func (m *SQLDB) PingContext(ctx context.Context) error {
+	if m.PingContextFunc == nil {
+		return nil
+	}
+	return m.PingContextFunc(ctx)
+}
+
+func (m *SQLDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if m.ExecContextFunc == nil {
+		return Result{}, nil
+	}
+	return m.ExecContextFunc(ctx, query, args...)
+}
+
+func (m *SQLDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if m.QueryContextFunc == nil {
+		return nil, errors.New("mock: QueryContextFunc not set")
+	}
+	return m.QueryContextFunc(ctx, query, args...)
+}
+
+func (m *SQLDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	if m.QueryRowContextFunc == nil {
+		return nil
+	}
+	return m.QueryRowContextFunc(ctx, query, args...)
+}
+
+func (m *SQLDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	if m.BeginTxFunc == nil {
+		return nil, errors.New("mock: BeginTxFunc not set")
+	}
+	return m.BeginTxFunc(ctx, opts)
+}
+
+func (m *SQLDB) Close() error {
+	if m.CloseFunc == nil {
+		return nil
+	}
+	return m.CloseFunc()
+}
+
+// Result is a sql.Result with fixed values.
+type Result struct {
+	LastID   int64
+	Affected int64
+}
+
+func (r Result) LastInsertId() (int64, error) { return r.LastID, nil }
+func (r Result) RowsAffected() (int64, error) { return r.Affected, nil }
+
+// Redis is an in-memory pkg.RedisClient. Get returns redis.Nil for missing or expired keys.
+// Set PingErr to simulate an unreachable server. The zero value is ready to use and safe for concurrent use.
+type Redis struct {
+	PingErr error
+
+	mu      sync.Mutex
+	values  map[string]string
+	expires map[string]time.Time
+	closed  bool
+}
+
+func (r *Redis) Ping(context.Context) *redis.StatusCmd {
+	if r.PingErr != nil {
+		return redis.NewStatusResult("", r.PingErr)
+	}
+	return redis.NewStatusResult("PONG", nil)
+}
+
+func (r *Redis) Get(_ context.Context, key string) *redis.StringCmd {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	value, ok := r.values[key]
+	if !ok || r.expiredLocked(key) {
+		return redis.NewStringResult("", redis.Nil)
+	}
+	return redis.NewStringResult(value, nil)
+}
+
+// Set stores the value formatted like the real client does for strings, []byte, numbers and booleans.
+func (r *Redis) Set(_ context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.values == nil {
+		r.values = map[string]string{}
+		r.expires = map[string]time.Time{}
+	}
+	r.values[key] = formatValue(value)
+	delete(r.expires, key)
+	if expiration > 0 {
+		r.expires[key] = time.Now().Add(expiration)
+	}
+	return redis.NewStatusResult("OK", nil)
+}
+
+func (r *Redis) Del(_ context.Context, keys ...string) *redis.IntCmd {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for _, key := range keys {
+		if _, ok := r.values[key]; ok && !r.expiredLocked(key) {
+			deleted++
+		}
+		delete(r.values, key)
+		delete(r.expires, key)
+	}
+	return redis.NewIntResult(deleted, nil)
+}
+
+// Close marks the client as closed; see Closed.
+func (r *Redis) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	return nil
+}
+
+// Closed reports whether Close has been called.
+func (r *Redis) Closed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.closed
+}
+
+// formatValue converts a Set value to the string Redis would store.
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (r *Redis) expiredLocked(key string) bool {
+	expiresAt, ok := r.expires[key]
+	return ok && !time.Now().Before(expiresAt)
+}
+
+// MongoClient is a pkg.MongoClient whose Ping and Disconnect call the corresponding function fields
+// and count the calls. Database returns nil unless DatabaseFunc is set.
+type MongoClient struct {
+	PingFunc       func(ctx context.Context, rp *readpref.ReadPref) error
+	DatabaseFunc   func(name string, opts ...options.Lister[options.DatabaseOptions]) *mongo.Database
+	DisconnectFunc func(ctx context.Context) error
+
+	mu              sync.Mutex
+	pingCalls       int
+	disconnectCalls int
+}
+
+func (m *MongoClient) Ping(ctx context.Context, rp *readpref.ReadPref) error {
+	m.mu.Lock()
+	m.pingCalls++
+	m.mu.Unlock()
+
+	if m.PingFunc == nil {
+		return nil
+	}
+	return m.PingFunc(ctx, rp)
+}
+
+func (m *MongoClient) Database(name string, opts ...options.Lister[options.DatabaseOptions]) *mongo.Database {
+	if m.DatabaseFunc == nil {
+		return nil
+	}
+	return m.DatabaseFunc(name, opts...)
+}
+
+func (m *MongoClient) Disconnect(ctx context.Context) error {
+	m.mu.Lock()
+	m.disconnectCalls++
+	m.mu.Unlock()
+
+	if m.DisconnectFunc == nil {
+		return nil
+	}
+	return m.DisconnectFunc(ctx)
+}
+
+// PingCalls returns how often Ping has been called.
+func (m *MongoClient) PingCalls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.pingCalls
+}
+
+// DisconnectCalls returns how often Disconnect has been called.
+func (m *MongoClient) DisconnectCalls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.disconnectCalls
+}
@@ -0,0 +1,98 @@
+package mock
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cherry-blossom1/go-database-connection/pkg"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisGetSetDel(t *testing.T) {
+	ctx := context.Background()
+	var r Redis
+
+	assert.ErrorIs(t, r.Get(ctx, "missing").Err(), redis.Nil)
+
+	assert.NoError(t, r.Set(ctx, "count", 42, 0).Err())
+	value, err := r.Get(ctx, "count").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "42", value)
+
+	assert.Equal(t, int64(1), r.Del(ctx, "count", "missing").Val())
+	assert.ErrorIs(t, r.Get(ctx, "count").Err(), redis.Nil)
+}
+
+func TestRedisExpiration(t *testing.T) {
+	ctx := context.Background()
+	var r Redis
+
+	assert.NoError(t, r.Set(ctx, "session", "abc", time.Millisecond).Err())
+	time.Sleep(5 * time.Millisecond)
+
+	assert.ErrorIs(t, r.Get(ctx, "session").Err(), redis.Nil)
+}
+
+func TestRedisPingErr(t *testing.T) {
+	down := errors.New("connection refused")
+	r := Redis{PingErr: down}
+
+	assert.ErrorIs(t, r.Ping(context.Background()).Err(), down)
+	assert.NoError(t, r.Close())
+	assert.True(t, r.Closed())
+}
+
+func TestSQLDBDefaultsAndFuncs(t *testing.T) {
+	ctx := context.Background()
+	var db SQLDB
+
+	assert.NoError(t, db.PingContext(ctx))
+	result, err := db.ExecContext(ctx, "DELETE FROM users")
+	assert.NoError(t, err)
+	affected, _ := result.RowsAffected()
+	assert.Equal(t, int64(0), affected)
+
+	var gotQuery string
+	db.ExecContextFunc = func(_ context.Context, query string, _ ...any) (sql.Result, error) {
+		gotQuery = query
+		return Result{Affected: 3}, nil
+	}
+
+	result, err = db.ExecContext(ctx, "DELETE FROM users WHERE active = ?", false)
+	assert.NoError(t, err)
+	affected, _ = result.RowsAffected()
+	assert.Equal(t, int64(3), affected)
+	assert.Equal(t, "DELETE FROM users WHERE active = ?", gotQuery)
+}
+
+func TestSQLDBUnsetFuncsReturnErrors(t *testing.T) {
+	ctx := context.Background()
+	var db SQLDB
+
+	rows, err := db.QueryContext(ctx, "SELECT id FROM users")
+	assert.Nil(t, rows)
+	assert.EqualError(t, err, "mock: QueryContextFunc not set")
+
+	tx, err := db.BeginTx(ctx, nil)
+	assert.Nil(t, tx)
+	assert.EqualError(t, err, "mock: BeginTxFunc not set")
+
+	err = pkg.WithTx(ctx, &db, func(*sql.Tx) error {
+		t.Fatal("Expected fn not to run without a transaction")
+		return nil
+	})
+	assert.ErrorContains(t, err, "mock: BeginTxFunc not set")
+}
+
+func TestMongoClientCountsCalls(t *testing.T) {
+	client := &MongoClient{DisconnectFunc: func(context.Context) error { return nil }}
+
+	assert.NoError(t, client.Ping(context.Background(), nil))
+	assert.NoError(t, client.Disconnect(context.Background()))
+	assert.Equal(t, 1, client.PingCalls())
+	assert.Equal(t, 1, client.DisconnectCalls())
+}
@@ -0,0 +1,83 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	_ "github.com/microsoft/go-mssqldb"
+)
+
+// MSSQLConfig describes a Microsoft SQL Server connection.
+// Set Port to connect to a fixed port, or Instance to connect to a named instance resolved through the SQL Browser service.
+type MSSQLConfig struct {
+	Host     string `json:"host" yaml:"host"`
+	Port     int    `json:"port" yaml:"port"`
+	Instance string `json:"instance" yaml:"instance"`
+	User     string `json:"user" yaml:"user"`
+	Password string `json:"password" yaml:"password"`
+	Database string `json:"database" yaml:"database"`
+	// Params holds additional connection parameters such as "encrypt" or "app name".
+	Params map[string]string `json:"params" yaml:"params"`
+}
+
+// BuildDSN formats the config as a sqlserver:// URL, escaping the credentials as needed.
+func (c MSSQLConfig) BuildDSN() string {
+	host := c.Host
+	if c.Port != 0 {
+		host = net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+	}
+
+	u := url.URL{
+		Scheme: "sqlserver",
+		Host:   host,
+		Path:   c.Instance,
+	}
+	if c.User != "" {
+		u.User = url.UserPassword(c.User, c.Password)
+	}
+
+	query := url.Values{}
+	if c.Database != "" {
+		query.Set("database", c.Database)
+	}
+	for key, value := range c.Params {
+		query.Set(key, value)
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+// NewMSSQLConnection establishes a connection to a Microsoft SQL Server database using the provided configuration.
+// It accepts either a connection string or an MSSQLConfig. After establishing the connection, it pings the database.
+// If successful, it returns the SQL database connection to interact with the database.
+// If any error occurs, it logs the error and terminates the application.
+func NewMSSQLConnection[T string | MSSQLConfig](cfg T, opts ...Option) *sql.DB {
+	db, err := NewMSSQLConnectionE(cfg, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to the SQL Server database: %v", err.Error())
+	}
+
+	return db
+}
+
+// NewMSSQLConnectionE establishes a connection to a Microsoft SQL Server database like NewMSSQLConnection,
+// but returns an error instead of terminating the application when the open or ping stage fails.
+func NewMSSQLConnectionE[T string | MSSQLConfig](cfg T, opts ...Option) (*sql.DB, error) {
+	var dsn string
+
+	switch v := any(cfg).(type) {
+	case string:
+		dsn = v
+	case MSSQLConfig:
+		dsn = v.BuildDSN()
+	default:
+		return nil, fmt.Errorf("invalid config type: %T", v)
+	}
+
+	return openSQL(context.Background(), "SQL Server", "mssql", "sqlserver", dsn, PoolConfig{}, newOptions(opts))
+}
@@ -0,0 +1,61 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// NewNeo4jConnection creates a Neo4j driver for uri, e.g. neo4j://localhost:7687, authenticated with auth
+// such as neo4j.BasicAuth(user, password, ""). Connectivity is verified before the driver is returned.
+// If any error occurs, it logs the error and terminates the application.
+func NewNeo4jConnection(uri string, auth neo4j.AuthToken, opts ...Option) neo4j.DriverWithContext {
+	driver, err := NewNeo4jConnectionCtx(context.Background(), uri, auth, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to Neo4j: %v", err.Error())
+	}
+
+	return driver
+}
+
+// NewNeo4jConnectionCtx creates a Neo4j driver like NewNeo4jConnection,
+// but bounds the verification with ctx and returns an error instead of terminating the application.
+// Credentials from the URI and from auth are masked in the returned error.
+func NewNeo4jConnectionCtx(ctx context.Context, uri string, auth neo4j.AuthToken, opts ...Option) (neo4j.DriverWithContext, error) {
+	return connectNeo4j(ctx, uri, auth, newOptions(opts))
+}
+
+// connectNeo4j creates the driver and verifies connectivity according to the retry policy in o.
+// The driver is closed if the verification fails.
+func connectNeo4j(ctx context.Context, uri string, auth neo4j.AuthToken, o *Options) (_ neo4j.DriverWithContext, err error) {
+	host := dsnHost(uri)
+	ctx, cancel := o.connectContext(ctx)
+	defer cancel()
+
+	ctx, span := o.startConnectSpan(ctx, "neo4j", host)
+	defer func() {
+		password, _ := auth.Tokens["credentials"].(string)
+		err = redactErr(err, uri, password)
+		endSpan(span, err)
+		o.finishConnect("neo4j", host, err)
+	}()
+
+	o.Logger.Infof("Connecting to Neo4j at %s", redactDSN(uri))
+
+	driver, err := neo4j.NewDriverWithContext(uri, auth)
+	if err != nil {
+		return nil, connectionError("neo4j", StageOpen, fmt.Errorf("failed to create Neo4j driver: %w", err))
+	}
+
+	err = o.ping(ctx, driver.VerifyConnectivity)
+	if err != nil {
+		_ = driver.Close(context.Background())
+		return nil, connectionError("neo4j", StagePing, fmt.Errorf("failed to verify Neo4j connectivity: %w", err))
+	}
+
+	o.Logger.Infof("Successfully connected to Neo4j")
+	o.track(driver)
+
+	return driver, nil
+}
@@ -0,0 +1,32 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNeo4jConnection(t *testing.T) {
+	driver := NewNeo4jConnection("neo4j://localhost:7687", neo4j.BasicAuth("neo4j", "password", ""))
+	defer driver.Close(context.Background())
+
+	err := driver.VerifyConnectivity(context.Background())
+	assert.NoError(t, err, "Expected no error when verifying Neo4j connectivity")
+}
+
+func TestNewNeo4jConnectionCtxInvalidScheme(t *testing.T) {
+	driver, err := NewNeo4jConnectionCtx(context.Background(), "http://localhost:7474", neo4j.NoAuth())
+
+	assert.Nil(t, driver)
+	assert.ErrorContains(t, err, "failed to create Neo4j driver")
+}
+
+func TestNewNeo4jConnectionCtxUnreachable(t *testing.T) {
+	driver, err := NewNeo4jConnectionCtx(context.Background(), "bolt://127.0.0.1:1", neo4j.BasicAuth("neo4j", "s3cret", ""))
+
+	assert.Nil(t, driver)
+	assert.ErrorContains(t, err, "failed to verify Neo4j connectivity")
+	assert.NotContains(t, err.Error(), "s3cret")
+}
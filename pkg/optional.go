@@ -0,0 +1,69 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrRedisUnavailable is returned by the writes of the client from NewOptionalRedisConnection
+// when Redis could not be reached at startup.
+var ErrRedisUnavailable = errors.New("redis is unavailable")
+
+// NewOptionalRedisConnection connects to a Redis server used as an optional cache. It behaves like
+// NewRedisConnectionE, but if the connection fails it logs a warning and returns a stand-in client instead
+// of an error: Get reports a cache miss with redis.Nil, Set and Del do nothing and return ErrRedisUnavailable,
+// and Ping returns ErrRedisUnavailable. The stand-in does not reconnect, so a Redis server that comes up
+// later is only used after the application restarts.
+// Only an unreachable server is tolerated: if the configuration is invalid, such as a malformed URL,
+// it logs the error and terminates the application like NewRedisConnection.
+func NewOptionalRedisConnection[T string | *redis.Options | RedisConfig](cfg T, opts ...Option) RedisClient {
+	client, err := NewRedisConnectionE(cfg, opts...)
+	if err != nil {
+		var connErr *ConnectionError
+		if !errors.As(err, &connErr) || (connErr.Stage != StageOpen && connErr.Stage != StagePing) {
+			fatalf(newOptions(opts).Logger, "Failed to connect to Redis: %v", err.Error())
+		}
+		newOptions(opts).Logger.Warnf("Redis is unavailable, continuing without cache: %v", err)
+		return unavailableRedis{}
+	}
+
+	return client
+}
+
+// unavailableRedis stands in for a Redis client that could not connect.
+type unavailableRedis struct{}
+
+func (unavailableRedis) Ping(ctx context.Context) *redis.StatusCmd {
+	cmd := redis.NewStatusCmd(ctx, "ping")
+	cmd.SetErr(ErrRedisUnavailable)
+	return cmd
+}
+
+func (unavailableRedis) Get(ctx context.Context, key string) *redis.StringCmd {
+	cmd := redis.NewStringCmd(ctx, "get", key)
+	cmd.SetErr(redis.Nil)
+	return cmd
+}
+
+func (unavailableRedis) Set(ctx context.Context, key string, value any, _ time.Duration) *redis.StatusCmd {
+	cmd := redis.NewStatusCmd(ctx, "set", key, value)
+	cmd.SetErr(ErrRedisUnavailable)
+	return cmd
+}
+
+func (unavailableRedis) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	args := []any{"del"}
+	for _, key := range keys {
+		args = append(args, key)
+	}
+	cmd := redis.NewIntCmd(ctx, args...)
+	cmd.SetErr(ErrRedisUnavailable)
+	return cmd
+}
+
+func (unavailableRedis) Close() error {
+	return nil
+}
@@ -0,0 +1,39 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOptionalRedisConnection(t *testing.T) {
+	client := NewOptionalRedisConnection("localhost:6379")
+	defer client.Close()
+
+	assert.IsType(t, &redis.Client{}, client)
+	assert.NoError(t, client.Ping(context.Background()).Err())
+}
+
+func TestNewOptionalRedisConnectionUnavailable(t *testing.T) {
+	ctx := context.Background()
+
+	client := NewOptionalRedisConnection("127.0.0.1:1")
+
+	assert.ErrorIs(t, client.Ping(ctx).Err(), ErrRedisUnavailable)
+	assert.ErrorIs(t, client.Get(ctx, "user:1").Err(), redis.Nil, "Expected a cache miss")
+	assert.ErrorIs(t, client.Set(ctx, "user:1", "alice", time.Minute).Err(), ErrRedisUnavailable)
+	assert.ErrorIs(t, client.Del(ctx, "user:1").Err(), ErrRedisUnavailable)
+	assert.NoError(t, client.Close())
+}
+
+func TestNewOptionalRedisConnectionInvalidConfig(t *testing.T) {
+	FailMode = FailPanic
+	defer func() { FailMode = FailFatal }()
+
+	assert.Panics(t, func() {
+		NewOptionalRedisConnection("http://localhost:6379")
+	}, "Expected a configuration error not to be degraded to a stand-in client")
+}
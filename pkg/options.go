@@ -0,0 +1,138 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Options holds the settings shared by the connectors in this package.
+// Connectors build it from the Option values passed by the caller.
+type Options struct {
+	// Retry controls how the startup ping is retried before the connector gives up.
+	Retry RetryConfig
+	// Logger receives the connector's log output. Defaults to the package-level logrus logger.
+	Logger Logger
+	// Connections, when set, tracks the handle returned by the connector.
+	Connections *Connections
+	// TracerProvider creates the "db.connect" spans. Defaults to the global OpenTelemetry provider.
+	TracerProvider trace.TracerProvider
+	// SkipPing returns the handle without checking that the server is reachable.
+	SkipPing bool
+	// OnConnect is called after a successful connection. See WithOnConnect.
+	OnConnect func(driver, host string)
+	// OnError is called when connecting fails. See WithOnError.
+	OnError func(stage Stage, err error)
+	// Context, when set, cancels connecting once it is done. See WithContext.
+	Context context.Context
+	// ConnectTimeout, when positive, bounds the time a connector may take to connect. See WithConnectTimeout.
+	ConnectTimeout time.Duration
+	// HTTPClient, when set, sends the requests of HTTP based drivers. See WithHTTPClient.
+	HTTPClient *http.Client
+
+	redis           redisSettings
+	cassandra       cassandraSettings
+	google          googleSettings
+	checkMariaDB    bool
+	checkTiDB       bool
+	name            string
+	verifyWritable  bool
+	verifySQL       func(context.Context, *sql.DB) error
+	mysqlTimeouts   mysqlTimeouts
+	migrationDryRun bool
+	driverName      string
+	mongoSchemes    []string
+	probeQuery      string
+	sshTunnel       *SSHTunnelConfig
+	sqliteCacheMode string
+	// inspect runs on the connected handle before it is tracked, registered or reported to OnConnect,
+	// so that a failure leaves nothing behind. It is set by the *WithInfo variants.
+	inspect func(conn any) error
+}
+
+// Option configures the Options used by a connector.
+type Option func(*Options)
+
+// WithRetry retries the startup ping according to cfg instead of failing on the first error.
+func WithRetry(cfg RetryConfig) Option {
+	return func(o *Options) {
+		o.Retry = cfg
+	}
+}
+
+// WithoutPing skips the startup ping, so the connector returns the handle as soon as it is created and the
+// connection is only established by the first real operation. This suits serverless cold starts and
+// servers that come up after the application. Configuration errors are still reported.
+func WithoutPing() Option {
+	return func(o *Options) {
+		o.SkipPing = true
+	}
+}
+
+// WithContext cancels connecting, including the ping and its retries, once ctx is done.
+// It applies to every connector that takes Option values, including those without a ctx parameter, which
+// otherwise use context.Background.
+// When a connector is also given a ctx directly, connecting stops as soon as either of the two is done;
+// values such as the parent span are taken from the ctx passed directly.
+func WithContext(ctx context.Context) Option {
+	return func(o *Options) {
+		o.Context = ctx
+	}
+}
+
+// WithConnectTimeout bounds the time a connector may take to connect, including the ping and its retries.
+// The timeout applies on top of the contexts: the earliest of the ctx passed to the connector, the Context set
+// with WithContext and the timeout ends the attempt.
+func WithConnectTimeout(d time.Duration) Option {
+	return func(o *Options) {
+		o.ConnectTimeout = d
+	}
+}
+
+// connectContext derives the context of a connection attempt from ctx, the Context and the ConnectTimeout.
+// The returned cancel function must be called once connecting is done.
+func (o *Options) connectContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	cancels := []context.CancelFunc{cancel}
+
+	if o.Context != nil {
+		stop := context.AfterFunc(o.Context, cancel)
+		cancels = append(cancels, func() { stop() })
+	}
+	if o.ConnectTimeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, o.ConnectTimeout)
+		cancels = append(cancels, cancelTimeout)
+	}
+
+	return ctx, func() {
+		for i := len(cancels) - 1; i >= 0; i-- {
+			cancels[i]()
+		}
+	}
+}
+
+// ping runs the startup check fn according to the retry policy, unless the ping is disabled.
+func (o *Options) ping(ctx context.Context, fn func(context.Context) error) error {
+	if o.SkipPing {
+		o.Logger.Infof("Skipping the startup ping")
+		return nil
+	}
+
+	return o.Retry.do(ctx, o.Logger, fn)
+}
+
+// newOptions applies opts on top of the default Options.
+func newOptions(opts []Option) *Options {
+	o := &Options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.Logger == nil {
+		o.Logger = defaultLogger()
+	}
+	return o
+}
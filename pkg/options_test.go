@@ -0,0 +1,81 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithoutPingReturnsUnreachableHandles(t *testing.T) {
+	db, err := NewPostgresDBConnectionE("postgres://postgres@127.0.0.1:1/testdb?sslmode=disable", WithoutPing())
+	assert.NoError(t, err)
+	assert.NotNil(t, db)
+	assert.Error(t, db.Ping(), "Expected the first real operation to fail")
+	db.Close()
+
+	client, err := NewRedisConnectionE("127.0.0.1:1", WithoutPing())
+	assert.NoError(t, err)
+	assert.NotNil(t, client)
+	client.Close()
+
+	mongoClient, err := NewMongoDBConnectionCtx(context.Background(), "mongodb://127.0.0.1:1", WithoutPing())
+	assert.NoError(t, err)
+	assert.NotNil(t, mongoClient)
+	_ = mongoClient.Disconnect(context.Background())
+}
+
+func TestWithoutPingStillValidatesConfig(t *testing.T) {
+	_, err := NewSQLDBConnectionE("not a valid dsn", WithoutPing())
+	assert.Error(t, err)
+
+	_, err = NewMongoDBConnectionCtx(context.Background(), "http://localhost:27017", WithoutPing())
+	assert.Error(t, err)
+}
+
+func TestConnectContextAppliesTimeout(t *testing.T) {
+	o := newOptions([]Option{WithConnectTimeout(time.Minute)})
+
+	ctx, cancel := o.connectContext(context.Background())
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+}
+
+func TestConnectContextFollowsOptionsContext(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	o := newOptions([]Option{WithContext(parent)})
+
+	ctx, cancel := o.connectContext(context.Background())
+	defer cancel()
+
+	assert.NoError(t, ctx.Err())
+	cancelParent()
+	<-ctx.Done()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestConnectContextKeepsEarlierDeadline(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), time.Second)
+	defer cancelCtx()
+	o := newOptions([]Option{WithConnectTimeout(time.Hour)})
+
+	got, cancel := o.connectContext(ctx)
+	defer cancel()
+
+	want, _ := ctx.Deadline()
+	deadline, _ := got.Deadline()
+	assert.Equal(t, want, deadline)
+}
+
+func TestWithConnectTimeoutBoundsRetries(t *testing.T) {
+	start := time.Now()
+	_, err := NewPostgresDBConnectionE("postgres://postgres@127.0.0.1:1/testdb?sslmode=disable",
+		WithRetry(RetryConfig{Attempts: 10, InitialDelay: time.Second}), WithConnectTimeout(200*time.Millisecond))
+
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second, "Expected the timeout to stop the retries")
+}
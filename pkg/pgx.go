@@ -0,0 +1,71 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// NewPostgresPgxConnection establishes a connection to a PostgreSQL database through the pgx driver.
+// It accepts either a connection string or a *pgx.ConnConfig and returns a pinged *sql.DB backed by pgx,
+// which brings statement caching and better COPY and LISTEN/NOTIFY support than NewPostgresDBConnection.
+// If any error occurs, it logs the error and terminates the application.
+func NewPostgresPgxConnection[T string | *pgx.ConnConfig](cfg T, opts ...Option) *sql.DB {
+	db, err := NewPostgresPgxConnectionCtx(context.Background(), cfg, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to the PostgreSQL database: %v", err.Error())
+	}
+
+	return db
+}
+
+// NewPostgresPgxConnectionCtx establishes a connection to a PostgreSQL database like NewPostgresPgxConnection,
+// but bounds the ping with ctx and returns an error instead of terminating the application.
+func NewPostgresPgxConnectionCtx[T string | *pgx.ConnConfig](ctx context.Context, cfg T, opts ...Option) (*sql.DB, error) {
+	o := newOptions(opts)
+
+	var connConfig *pgx.ConnConfig
+
+	switch v := any(cfg).(type) {
+	case string:
+		parsed, err := pgx.ParseConfig(v)
+		if err != nil {
+			return nil, o.failed(redactErr(connectionError("postgresql", StageConfig, fmt.Errorf("invalid PostgreSQL connection string: %w", err)), v))
+		}
+		connConfig = parsed
+	case *pgx.ConnConfig:
+		connConfig = v
+	default:
+		return nil, fmt.Errorf("invalid config type: %T", v)
+	}
+
+	db, err := openPgx(ctx, connConfig, o)
+	if err != nil {
+		return nil, o.failed(err)
+	}
+
+	return pingSQL(ctx, "PostgreSQL", "postgresql", net.JoinHostPort(connConfig.Host, strconv.Itoa(int(connConfig.Port))), connConfig.ConnString(), db, PoolConfig{}, o)
+}
+
+// openPgx opens a *sql.DB backed by pgx for connConfig, dialing through the SSH tunnel configured in o, if any.
+func openPgx(ctx context.Context, connConfig *pgx.ConnConfig, o *Options, opts ...stdlib.OptionOpenDB) (*sql.DB, error) {
+	if o.sshTunnel == nil {
+		return stdlib.OpenDB(*connConfig, opts...), nil
+	}
+
+	return o.openSQLConnector(ctx, "postgresql", func(dial dialContextFunc) (driver.Connector, error) {
+		tunneled := connConfig.Copy()
+		tunneled.DialFunc = dial
+		// Host names are resolved by the SSH server, not locally.
+		tunneled.LookupFunc = func(_ context.Context, host string) ([]string, error) {
+			return []string{host}, nil
+		}
+		return stdlib.GetConnector(*tunneled, opts...), nil
+	})
+}
@@ -0,0 +1,66 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NewPostgresPoolConnection creates a native pgx connection pool for a PostgreSQL database and pings it.
+// It accepts either a connection string or a *pgxpool.Config. The pool is sized and maintained by the config:
+// set MaxConns, MinConns, MaxConnLifetime and HealthCheckPeriod on it, or pass them in the connection string
+// as pool_max_conns, pool_min_conns, pool_max_conn_lifetime and pool_health_check_period.
+// The ping is bounded by ctx and the pool is closed if it fails.
+func NewPostgresPoolConnection[T string | *pgxpool.Config](ctx context.Context, cfg T, opts ...Option) (*pgxpool.Pool, error) {
+	o := newOptions(opts)
+
+	var poolConfig *pgxpool.Config
+
+	switch v := any(cfg).(type) {
+	case string:
+		parsed, err := pgxpool.ParseConfig(v)
+		if err != nil {
+			return nil, o.failed(redactErr(connectionError("postgresql", StageConfig, fmt.Errorf("invalid PostgreSQL connection string: %w", err)), v))
+		}
+		poolConfig = parsed
+	case *pgxpool.Config:
+		poolConfig = v
+	default:
+		return nil, fmt.Errorf("invalid config type: %T", v)
+	}
+
+	return connectPostgresPool(ctx, poolConfig, o)
+}
+
+// connectPostgresPool creates the pool and pings it according to the retry policy in o.
+func connectPostgresPool(ctx context.Context, cfg *pgxpool.Config, o *Options) (_ *pgxpool.Pool, err error) {
+	host := net.JoinHostPort(cfg.ConnConfig.Host, strconv.Itoa(int(cfg.ConnConfig.Port)))
+	ctx, cancel := o.connectContext(ctx)
+	defer cancel()
+
+	ctx, span := o.startConnectSpan(ctx, "postgresql", host)
+	defer func() {
+		err = redactErr(err, cfg.ConnString(), cfg.ConnConfig.Password)
+		endSpan(span, err)
+		o.finishConnect("postgresql", host, err)
+	}()
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		return nil, connectionError("postgresql", StageOpen, fmt.Errorf("failed to create PostgreSQL pool: %w", err))
+	}
+
+	o.Logger.Infof("Trying to ping the PostgreSQL pool")
+	err = o.ping(ctx, pool.Ping)
+	if err != nil {
+		pool.Close()
+		return nil, connectionError("postgresql", StagePing, fmt.Errorf("failed to ping PostgreSQL pool: %w", err))
+	}
+
+	o.Logger.Infof("Successfully connected to the PostgreSQL pool")
+	o.track(pool)
+	return pool, nil
+}
@@ -0,0 +1,73 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// NewPlanetScaleConnection establishes a connection to a PlanetScale database through the MySQL driver.
+// It accepts either the DSN shown in the PlanetScale console or a MySQL config object. PlanetScale only
+// accepts TLS connections, so tls defaults to true and disabling it is an error, and interpolateParams is
+// enabled to save the round trip of a server-side prepared statement per query, as PlanetScale recommends.
+// PlanetScale runs Vitess, which historically rejects foreign key constraints and some statements such as
+// LOCK TABLES, so schemas and migrations written for MySQL may need adjusting.
+// If any error occurs, it logs the error and terminates the application.
+func NewPlanetScaleConnection[T string | mysql.Config](cfg T, opts ...Option) *sql.DB {
+	db, err := NewPlanetScaleConnectionE(cfg, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to the PlanetScale database: %v", err.Error())
+	}
+
+	return db
+}
+
+// NewPlanetScaleConnectionE establishes a connection to a PlanetScale database like NewPlanetScaleConnection,
+// but returns an error instead of terminating the application.
+func NewPlanetScaleConnectionE[T string | mysql.Config](cfg T, opts ...Option) (*sql.DB, error) {
+	o := newOptions(opts)
+
+	var mysqlCfg *mysql.Config
+
+	switch v := any(cfg).(type) {
+	case string:
+		parsed, err := mysql.ParseDSN(v)
+		if err != nil {
+			return nil, o.failed(redactErr(connectionError("planetscale", StageConfig, fmt.Errorf("invalid PlanetScale DSN: %w", err)), v))
+		}
+		mysqlCfg = parsed
+	case mysql.Config:
+		mysqlCfg = v.Clone()
+		o.mysqlTimeouts.apply(mysqlCfg)
+		applyMySQLProgramName(mysqlCfg)
+	default:
+		return nil, fmt.Errorf("invalid config type: %T", v)
+	}
+
+	if err := applyPlanetScaleDefaults(mysqlCfg); err != nil {
+		return nil, o.failed(connectionError("planetscale", StageConfig, err))
+	}
+
+	return openSQL(context.Background(), "PlanetScale", "planetscale", "mysql", mysqlCfg.FormatDSN(), PoolConfig{}, o)
+}
+
+// applyPlanetScaleDefaults enables TLS and parameter interpolation on cfg. It returns an error if cfg disables TLS.
+// A custom cfg.TLS is registered with the driver, since it would otherwise be lost when cfg is formatted as a DSN.
+func applyPlanetScaleDefaults(cfg *mysql.Config) error {
+	switch cfg.TLSConfig {
+	case "":
+		if cfg.TLS == nil {
+			cfg.TLSConfig = "true"
+		} else if _, err := registerMySQLTLS(cfg, cfg.TLS); err != nil {
+			return err
+		}
+	case "false":
+		return errors.New("PlanetScale requires TLS: remove tls=false from the DSN")
+	}
+	cfg.InterpolateParams = true
+
+	return nil
+}
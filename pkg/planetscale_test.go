@@ -0,0 +1,44 @@
+package pkg
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPlanetScaleDefaults(t *testing.T) {
+	cfg, err := mysql.ParseDSN("user:pscale_pw_s3cret@tcp(aws.connect.psdb.cloud)/orders")
+	require.NoError(t, err)
+
+	require.NoError(t, applyPlanetScaleDefaults(cfg))
+	assert.Equal(t, "true", cfg.TLSConfig)
+	assert.True(t, cfg.InterpolateParams)
+
+	custom := mysql.NewConfig()
+	custom.TLS = &tls.Config{ServerName: "aws.connect.psdb.cloud"}
+
+	require.NoError(t, applyPlanetScaleDefaults(custom))
+	assert.NotEmpty(t, custom.TLSConfig)
+	assert.NotEqual(t, "true", custom.TLSConfig)
+}
+
+func TestNewPlanetScaleConnectionERequiresTLS(t *testing.T) {
+	db, err := NewPlanetScaleConnectionE("user:pscale_pw_s3cret@tcp(aws.connect.psdb.cloud)/orders?tls=false")
+
+	assert.Nil(t, db)
+	var connErr *ConnectionError
+	require.ErrorAs(t, err, &connErr)
+	assert.Equal(t, StageConfig, connErr.Stage)
+	assert.ErrorContains(t, err, "PlanetScale requires TLS")
+}
+
+func TestNewPlanetScaleConnectionEMalformedDSN(t *testing.T) {
+	db, err := NewPlanetScaleConnectionE("user:pscale_pw_s3cret@tcp(aws.connect.psdb.cloud)orders")
+
+	assert.Nil(t, db)
+	assert.ErrorContains(t, err, "invalid PlanetScale DSN")
+	assert.NotContains(t, err.Error(), "pscale_pw_s3cret")
+}
@@ -0,0 +1,37 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	// DefaultProbeQuery is a probe query accepted by the supported SQL databases except Oracle before 23c,
+	// which requires a FROM clause. The Oracle connector runs OracleProbeQuery in its place.
+	DefaultProbeQuery = "SELECT 1"
+	// OracleProbeQuery is the probe query for Oracle databases of every version.
+	OracleProbeQuery = "SELECT 1 FROM DUAL"
+)
+
+// WithProbeQuery makes the SQL connectors run query after the ping and fail with its error, for example
+// WithProbeQuery("SELECT 1 FROM orders LIMIT 1") to check that the user may read the table it needs.
+// A ping only proves that the server accepted the login, not that queries on the target database succeed.
+// Any rows the query returns are discarded. It has no effect together with WithoutPing.
+func WithProbeQuery(query string) Option {
+	return func(o *Options) {
+		o.probeQuery = query
+	}
+}
+
+// probeSQL runs query on db and reads its result to the end, so that errors raised while streaming rows surface too.
+func probeSQL(ctx context.Context, db *sql.DB, query string) error {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+	}
+	return rows.Err()
+}
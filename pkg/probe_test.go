@@ -0,0 +1,32 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithProbeQuery(t *testing.T) {
+	db, err := NewSQLiteConnectionWithConfigE(SQLiteConfig{Path: SQLiteMemory}, WithProbeQuery(DefaultProbeQuery))
+
+	assert.NoError(t, err)
+	assert.NoError(t, db.Close())
+}
+
+func TestWithProbeQueryReturnsQueryError(t *testing.T) {
+	db, err := NewSQLiteConnectionWithConfigE(SQLiteConfig{Path: SQLiteMemory}, WithProbeQuery("SELECT 1 FROM orders LIMIT 1"))
+
+	assert.Nil(t, db)
+	assert.ErrorContains(t, err, "no such table: orders")
+
+	var connErr *ConnectionError
+	assert.ErrorAs(t, err, &connErr)
+	assert.Equal(t, StageVerify, connErr.Stage)
+}
+
+func TestWithProbeQuerySkippedWithoutPing(t *testing.T) {
+	db, err := NewSQLiteConnectionWithConfigE(SQLiteConfig{Path: SQLiteMemory}, WithProbeQuery("SELECT 1 FROM orders"), WithoutPing())
+
+	assert.NoError(t, err)
+	assert.NoError(t, db.Close())
+}
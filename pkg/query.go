@@ -0,0 +1,51 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// ExecContext runs query on db like db.ExecContext, but bounds the call with timeout on top of ctx, so a slow
+// statement fails with context.DeadlineExceeded instead of blocking. The timeout is per call: every call gets
+// the full timeout, regardless of earlier calls. A zero or negative timeout only applies ctx.
+func ExecContext(ctx context.Context, db SQLDB, timeout time.Duration, query string, args ...any) (sql.Result, error) {
+	ctx, cancel := withQueryTimeout(ctx, timeout)
+	defer cancel()
+
+	return db.ExecContext(ctx, query, args...)
+}
+
+// Row is the result of QueryRowContext. Like *sql.Row, it reports errors when it is scanned.
+type Row struct {
+	row    *sql.Row
+	cancel context.CancelFunc
+}
+
+// Scan copies the columns of the row into dest like (*sql.Row).Scan and releases the timeout of the query.
+func (r *Row) Scan(dest ...any) error {
+	defer r.cancel()
+	return r.row.Scan(dest...)
+}
+
+// Err returns the error of the query like (*sql.Row).Err, without scanning the row.
+func (r *Row) Err() error {
+	return r.row.Err()
+}
+
+// QueryRowContext runs a query returning at most one row on db like db.QueryRowContext, but bounds the query and
+// the Scan of its row with timeout on top of ctx. The timeout is per call, like with ExecContext. It stays in
+// effect until Scan returns, so the row must be scanned to release it.
+func QueryRowContext(ctx context.Context, db SQLDB, timeout time.Duration, query string, args ...any) *Row {
+	ctx, cancel := withQueryTimeout(ctx, timeout)
+
+	return &Row{row: db.QueryRowContext(ctx, query, args...), cancel: cancel}
+}
+
+// withQueryTimeout derives the context of a single query from ctx and timeout.
+func withQueryTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
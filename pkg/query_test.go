@@ -0,0 +1,49 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowSQLiteQuery counts to a billion, which takes far longer than the timeouts in the tests.
+const slowSQLiteQuery = "WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 1000000000) SELECT count(*) FROM n"
+
+func TestExecContext(t *testing.T) {
+	db := openTestSQLite(t)
+	ctx := context.Background()
+
+	_, err := ExecContext(ctx, db, time.Second, "CREATE TABLE items (name TEXT)")
+	require.NoError(t, err)
+
+	result, err := ExecContext(ctx, db, time.Second, "INSERT INTO items (name) VALUES (?), (?)", "a", "b")
+	require.NoError(t, err)
+	rows, err := result.RowsAffected()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, rows)
+}
+
+func TestExecContextTimeout(t *testing.T) {
+	db := openTestSQLite(t)
+
+	start := time.Now()
+	_, err := ExecContext(context.Background(), db, 50*time.Millisecond, slowSQLiteQuery)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestQueryRowContext(t *testing.T) {
+	db := openTestSQLite(t)
+
+	var sum int
+	require.NoError(t, QueryRowContext(context.Background(), db, time.Second, "SELECT ? + ?", 2, 3).Scan(&sum))
+	assert.Equal(t, 5, sum)
+
+	var count int
+	err := QueryRowContext(context.Background(), db, 50*time.Millisecond, slowSQLiteQuery).Scan(&count)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
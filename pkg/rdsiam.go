@@ -0,0 +1,201 @@
+package pkg
+
+import (
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/rds/auth"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// RDSIAMConfig describes an Amazon RDS or Aurora database that authenticates with IAM instead of a password.
+type RDSIAMConfig struct {
+	// Host is the endpoint of the instance or cluster, e.g. "orders.abc123.eu-west-1.rds.amazonaws.com".
+	Host string `json:"host" yaml:"host"`
+	// Port defaults to 5432 for PostgreSQL and 3306 for MySQL.
+	Port int `json:"port" yaml:"port"`
+	// Region is the AWS region of the database. Defaults to the region of the shared AWS configuration
+	// and the AWS_REGION environment variable.
+	Region string `json:"region" yaml:"region"`
+	// User is the database user, which must be granted rds_iam on PostgreSQL or use AWSAuthenticationPlugin on MySQL.
+	User   string `json:"user" yaml:"user"`
+	DBName string `json:"db_name" yaml:"db_name"`
+	// TLSConfig verifies the server, typically built with LoadTLSConfig from the RDS CA bundle at
+	// https://truststore.pki.rds.amazonaws.com/global/global-bundle.pem. IAM authentication requires TLS.
+	// Without a TLSConfig the certificate is verified against the system roots, which usually lack the RDS CA.
+	// The ServerName defaults to Host in both cases.
+	TLSConfig *tls.Config `json:"-" yaml:"-"`
+	// InsecureSkipVerify encrypts the connection without verifying the server certificate,
+	// which exposes the IAM token to anyone able to intercept the connection.
+	InsecureSkipVerify bool `json:"insecure_skip_verify" yaml:"insecure_skip_verify"`
+	// Credentials signs the tokens. Defaults to the default credential chain: environment variables,
+	// shared files, and the instance or task role.
+	Credentials aws.CredentialsProvider `json:"-" yaml:"-"`
+}
+
+// tlsConfig returns the TLS configuration the connection to the database uses.
+func (c RDSIAMConfig) tlsConfig() *tls.Config {
+	cfg := &tls.Config{}
+	if c.TLSConfig != nil {
+		cfg = c.TLSConfig.Clone()
+	}
+	if cfg.ServerName == "" {
+		cfg.ServerName = c.Host
+	}
+	if c.InsecureSkipVerify {
+		cfg.InsecureSkipVerify = true
+	}
+	return cfg
+}
+
+// rdsTokenFunc returns a fresh IAM authentication token.
+type rdsTokenFunc func(ctx context.Context) (string, error)
+
+// rdsTokens resolves the AWS region and credentials for cfg and returns the endpoint of the database
+// together with a function building authentication tokens for it. Tokens are signed locally,
+// so building one per connection costs no round trip.
+func rdsTokens(ctx context.Context, cfg RDSIAMConfig, defaultPort int, o *Options) (string, rdsTokenFunc, error) {
+	if cfg.Port == 0 {
+		cfg.Port = defaultPort
+	}
+	endpoint := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+
+	if cfg.Region == "" || cfg.Credentials == nil {
+		var loadOpts []func(*config.LoadOptions) error
+		if cfg.Region != "" {
+			loadOpts = append(loadOpts, config.WithRegion(cfg.Region))
+		}
+		if o.HTTPClient != nil {
+			loadOpts = append(loadOpts, config.WithHTTPClient(o.HTTPClient))
+		}
+
+		awsCfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+		}
+		cfg.Region = awsCfg.Region
+		if cfg.Credentials == nil {
+			cfg.Credentials = awsCfg.Credentials
+		}
+	}
+	if cfg.Region == "" {
+		return "", nil, errors.New("no AWS region configured for RDS IAM authentication")
+	}
+
+	return endpoint, func(ctx context.Context) (string, error) {
+		token, err := auth.BuildAuthToken(ctx, endpoint, cfg.Region, cfg.User, cfg.Credentials)
+		if err != nil {
+			return "", fmt.Errorf("failed to build RDS IAM authentication token: %w", err)
+		}
+		return token, nil
+	}, nil
+}
+
+// NewPostgresRDSIAMConnection establishes a connection to an RDS PostgreSQL database through the pgx driver,
+// authenticating with IAM tokens. Tokens expire after 15 minutes, so a new one is built for every connection
+// the pool opens; established connections stay authenticated after their token expires.
+// If any error occurs, it logs the error and terminates the application.
+func NewPostgresRDSIAMConnection(ctx context.Context, cfg RDSIAMConfig, opts ...Option) *sql.DB {
+	db, err := NewPostgresRDSIAMConnectionE(ctx, cfg, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to the PostgreSQL database: %v", err.Error())
+	}
+
+	return db
+}
+
+// NewPostgresRDSIAMConnectionE establishes a connection to an RDS PostgreSQL database like NewPostgresRDSIAMConnection,
+// but returns an error instead of terminating the application.
+func NewPostgresRDSIAMConnectionE(ctx context.Context, cfg RDSIAMConfig, opts ...Option) (*sql.DB, error) {
+	o := newOptions(opts)
+
+	endpoint, token, err := rdsTokens(ctx, cfg, 5432, o)
+	if err != nil {
+		return nil, o.failed(connectionError("postgresql", StageConfig, err))
+	}
+
+	connConfig, err := pgx.ParseConfig(PostgresConfig{Host: cfg.Host, Port: cfg.Port, User: cfg.User, DBName: cfg.DBName, SSLMode: "verify-full"}.BuildDSN())
+	if err != nil {
+		return nil, o.failed(connectionError("postgresql", StageConfig, fmt.Errorf("invalid PostgreSQL config: %w", err)))
+	}
+	connConfig.TLSConfig = cfg.tlsConfig()
+
+	db, err := openPgx(ctx, connConfig, o, stdlib.OptionBeforeConnect(func(ctx context.Context, c *pgx.ConnConfig) error {
+		password, err := token(ctx)
+		c.Password = password
+		return err
+	}))
+	if err != nil {
+		return nil, o.failed(err)
+	}
+
+	return pingSQL(ctx, "PostgreSQL", "postgresql", endpoint, "", db, PoolConfig{}, o)
+}
+
+// NewMySQLRDSIAMConnection establishes a connection to an RDS MySQL or Aurora MySQL database, authenticating
+// with IAM tokens. Tokens expire after 15 minutes, so a new one is built for every connection the pool opens;
+// established connections stay authenticated after their token expires.
+// If any error occurs, it logs the error and terminates the application.
+func NewMySQLRDSIAMConnection(ctx context.Context, cfg RDSIAMConfig, opts ...Option) *sql.DB {
+	db, err := NewMySQLRDSIAMConnectionE(ctx, cfg, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to the MySQL database: %v", err.Error())
+	}
+
+	return db
+}
+
+// NewMySQLRDSIAMConnectionE establishes a connection to an RDS MySQL database like NewMySQLRDSIAMConnection,
+// but returns an error instead of terminating the application.
+func NewMySQLRDSIAMConnectionE(ctx context.Context, cfg RDSIAMConfig, opts ...Option) (*sql.DB, error) {
+	o := newOptions(opts)
+
+	endpoint, token, err := rdsTokens(ctx, cfg, 3306, o)
+	if err != nil {
+		return nil, o.failed(connectionError("mysql", StageConfig, err))
+	}
+
+	mysqlCfg := mysql.NewConfig()
+	mysqlCfg.Net = "tcp"
+	mysqlCfg.Addr = endpoint
+	mysqlCfg.User = cfg.User
+	mysqlCfg.DBName = cfg.DBName
+	// The token is sent with the cleartext plugin, which is why the connection must use TLS.
+	mysqlCfg.AllowCleartextPasswords = true
+	mysqlCfg.TLS = cfg.tlsConfig()
+	o.mysqlTimeouts.apply(mysqlCfg)
+	applyMySQLProgramName(mysqlCfg)
+
+	err = mysqlCfg.Apply(mysql.BeforeConnect(func(ctx context.Context, c *mysql.Config) error {
+		password, err := token(ctx)
+		c.Passwd = password
+		return err
+	}))
+	if err != nil {
+		return nil, o.failed(connectionError("mysql", StageConfig, err))
+	}
+
+	db, err := o.openSQLConnector(ctx, "mysql", func(dial dialContextFunc) (driver.Connector, error) {
+		mysqlCfg.DialFunc = dial
+		connector, err := mysql.NewConnector(mysqlCfg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MySQL config: %w", err)
+		}
+		return connector, nil
+	})
+	if err != nil {
+		return nil, o.failed(err)
+	}
+
+	return pingSQL(ctx, "MySQL", "mysql", endpoint, "", db, PoolConfig{}, o)
+}
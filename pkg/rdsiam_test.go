@@ -0,0 +1,90 @@
+package pkg
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+// testRDSIAMConfig points at a closed local port with static credentials, so no AWS configuration is loaded.
+func testRDSIAMConfig() RDSIAMConfig {
+	return RDSIAMConfig{
+		Host:        "127.0.0.1",
+		Port:        1,
+		Region:      "eu-west-1",
+		User:        "app",
+		DBName:      "orders",
+		Credentials: credentials.NewStaticCredentialsProvider("AKIDEXAMPLE", "s3cret", ""),
+	}
+}
+
+func TestRDSTokensBuildsSignedTokens(t *testing.T) {
+	cfg := testRDSIAMConfig()
+	cfg.Port = 0
+
+	endpoint, token, err := rdsTokens(context.Background(), cfg, 5432, newOptions(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:5432", endpoint)
+
+	got, err := token(context.Background())
+	assert.NoError(t, err)
+	assert.Contains(t, got, "127.0.0.1:5432?Action=connect")
+	assert.Contains(t, got, "DBUser=app")
+	assert.Contains(t, got, "X-Amz-Signature=")
+	assert.NotContains(t, got, "s3cret")
+}
+
+func TestRDSTokensRequiresRegion(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	t.Setenv("AWS_CONFIG_FILE", t.TempDir()+"/config")
+
+	cfg := testRDSIAMConfig()
+	cfg.Region = ""
+
+	_, _, err := rdsTokens(context.Background(), cfg, 5432, newOptions(nil))
+	assert.ErrorContains(t, err, "no AWS region configured")
+}
+
+func TestNewPostgresRDSIAMConnectionEPingFails(t *testing.T) {
+	db, err := NewPostgresRDSIAMConnectionE(context.Background(), testRDSIAMConfig())
+
+	assert.Nil(t, db)
+	var connErr *ConnectionError
+	assert.ErrorAs(t, err, &connErr)
+	assert.Equal(t, StagePing, connErr.Stage)
+	assert.NotContains(t, err.Error(), "X-Amz-Signature")
+}
+
+func TestNewMySQLRDSIAMConnectionEPingFails(t *testing.T) {
+	db, err := NewMySQLRDSIAMConnectionE(context.Background(), testRDSIAMConfig())
+
+	assert.Nil(t, db)
+	var connErr *ConnectionError
+	assert.ErrorAs(t, err, &connErr)
+	assert.Equal(t, StagePing, connErr.Stage)
+}
+
+func TestRDSIAMConfigTLSConfig(t *testing.T) {
+	cfg := testRDSIAMConfig()
+	cfg.Host = "orders.abc123.eu-west-1.rds.amazonaws.com"
+
+	tlsCfg := cfg.tlsConfig()
+	assert.Equal(t, cfg.Host, tlsCfg.ServerName)
+	assert.False(t, tlsCfg.InsecureSkipVerify, "Expected the server certificate to be verified by default")
+	assert.Nil(t, tlsCfg.RootCAs)
+
+	roots := x509.NewCertPool()
+	cfg.TLSConfig = &tls.Config{RootCAs: roots}
+	tlsCfg = cfg.tlsConfig()
+	assert.Same(t, roots, tlsCfg.RootCAs)
+	assert.Equal(t, cfg.Host, tlsCfg.ServerName)
+	assert.Empty(t, cfg.TLSConfig.ServerName, "Expected the caller's TLS config to be left untouched")
+
+	cfg.InsecureSkipVerify = true
+	assert.True(t, cfg.tlsConfig().InsecureSkipVerify)
+}
@@ -0,0 +1,51 @@
+package pkg
+
+import (
+	"database/sql"
+	"sync"
+)
+
+// registry holds the SQL connections registered by name.
+var registry = struct {
+	sync.RWMutex
+	dbs map[string]*sql.DB
+}{dbs: map[string]*sql.DB{}}
+
+// Register makes db available under name to Get, so that different parts of an application can share a pool
+// without passing it around. A connection already registered under name is replaced.
+// It is safe for concurrent use.
+func Register(name string, db *sql.DB) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.dbs[name] = db
+}
+
+// Get returns the connection registered under name, and whether there is one.
+func Get(name string) (*sql.DB, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	db, ok := registry.dbs[name]
+	return db, ok
+}
+
+// Unregister removes the connection registered under name. It does not close the connection.
+func Unregister(name string) {
+	registry.Lock()
+	defer registry.Unlock()
+	delete(registry.dbs, name)
+}
+
+// WithName registers the *sql.DB returned by the connector under name once it is connected, see Register.
+// Connectors that return other handle types ignore it.
+func WithName(name string) Option {
+	return func(o *Options) {
+		o.name = name
+	}
+}
+
+// register registers db under the name configured in o, if any.
+func (o *Options) register(db *sql.DB) {
+	if o.name != "" {
+		Register(o.name, db)
+	}
+}
@@ -0,0 +1,124 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+)
+
+// ReplicaSet holds a PostgreSQL primary and its read replicas.
+// Writes go to Primary, reads to Replica, which round-robins across the replicas that passed the last health check.
+type ReplicaSet struct {
+	primary  *sql.DB
+	replicas []*sql.DB
+	healthy  []atomic.Bool
+	next     atomic.Uint64
+}
+
+// newReplicaSet builds a ReplicaSet with every replica marked healthy.
+func newReplicaSet(primary *sql.DB, replicas []*sql.DB) *ReplicaSet {
+	rs := &ReplicaSet{
+		primary:  primary,
+		replicas: replicas,
+		healthy:  make([]atomic.Bool, len(replicas)),
+	}
+	for i := range rs.healthy {
+		rs.healthy[i].Store(true)
+	}
+
+	return rs
+}
+
+// NewPostgresReplicaSet connects to a PostgreSQL primary and its read replicas, pinging each of them.
+// If any error occurs, it logs the error and terminates the application.
+func NewPostgresReplicaSet(primary string, replicas []string, opts ...Option) *ReplicaSet {
+	rs, err := NewPostgresReplicaSetE(primary, replicas, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to the PostgreSQL replica set: %v", err.Error())
+	}
+
+	return rs
+}
+
+// NewPostgresReplicaSetE connects to a PostgreSQL primary and its read replicas like NewPostgresReplicaSet,
+// but returns an error instead of terminating the application. Connections opened before the failure are closed.
+func NewPostgresReplicaSetE(primary string, replicas []string, opts ...Option) (*ReplicaSet, error) {
+	primaryDB, err := NewPostgresDBConnectionE(primary, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("primary: %w", err)
+	}
+
+	// WithName registers the primary only.
+	replicaOpts := append(slices.Clip(opts), WithName(""))
+
+	replicaDBs := make([]*sql.DB, 0, len(replicas))
+	for i, dsn := range replicas {
+		db, err := NewPostgresDBConnectionE(dsn, replicaOpts...)
+		if err != nil {
+			closeAll(append(replicaDBs, primaryDB))
+			return nil, fmt.Errorf("replica %d: %w", i, err)
+		}
+		replicaDBs = append(replicaDBs, db)
+	}
+
+	return newReplicaSet(primaryDB, replicaDBs), nil
+}
+
+// closeAll closes every database in dbs, ignoring errors.
+func closeAll(dbs []*sql.DB) {
+	for _, db := range dbs {
+		_ = db.Close()
+	}
+}
+
+// Primary returns the connection to the primary, which should be used for writes.
+func (rs *ReplicaSet) Primary() *sql.DB {
+	return rs.primary
+}
+
+// Replica returns the next healthy replica in round-robin order.
+// It falls back to the primary if there are no replicas or none of them is healthy.
+func (rs *ReplicaSet) Replica() *sql.DB {
+	n := len(rs.replicas)
+	if n == 0 {
+		return rs.primary
+	}
+
+	start := rs.next.Add(1) - 1
+	for i := range n {
+		idx := int((start + uint64(i)) % uint64(n))
+		if rs.healthy[idx].Load() {
+			return rs.replicas[idx]
+		}
+	}
+
+	return rs.primary
+}
+
+// CheckHealth pings every replica within ctx and updates which of them Replica may return.
+// It returns the joined ping errors of the unhealthy replicas, or nil if all of them are reachable.
+func (rs *ReplicaSet) CheckHealth(ctx context.Context) error {
+	var errs []error
+	for i, db := range rs.replicas {
+		err := HealthCheckSQL(ctx, db)
+		rs.healthy[i].Store(err == nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("replica %d: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close closes the primary and all replicas.
+func (rs *ReplicaSet) Close() error {
+	errs := []error{rs.primary.Close()}
+	for _, db := range rs.replicas {
+		errs = append(errs, db.Close())
+	}
+
+	return errors.Join(errs...)
+}
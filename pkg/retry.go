@@ -0,0 +1,104 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	defaultRetryInitialDelay = 500 * time.Millisecond
+	defaultRetryMaxDelay     = 30 * time.Second
+	defaultRetryMultiplier   = 2
+)
+
+// RetryConfig describes an exponential backoff policy for ping operations.
+// The zero value makes a single attempt, which matches the behavior without retries.
+type RetryConfig struct {
+	// Attempts is the total number of tries, including the first one.
+	Attempts int `json:"attempts" yaml:"attempts"`
+	// InitialDelay is the wait before the second attempt. Defaults to 500ms.
+	InitialDelay time.Duration `json:"initial_delay" yaml:"initial_delay"`
+	// MaxDelay caps the wait between attempts. Defaults to 30s.
+	MaxDelay time.Duration `json:"max_delay" yaml:"max_delay"`
+	// Multiplier grows the delay after every failed attempt. Defaults to 2.
+	Multiplier float64 `json:"multiplier" yaml:"multiplier"`
+	// Jitter randomizes the waits so that many instances started together, e.g. during a rolling restart,
+	// spread their retries instead of hitting the database in lockstep. Defaults to JitterNone.
+	Jitter Jitter `json:"jitter" yaml:"jitter"`
+}
+
+// Jitter selects how the backoff delay is randomized.
+type Jitter string
+
+const (
+	// JitterNone waits exactly the backoff delay.
+	JitterNone Jitter = ""
+	// JitterFull waits a random duration between zero and the backoff delay, which spreads retries the most.
+	JitterFull Jitter = "full"
+	// JitterEqual waits half the backoff delay plus a random duration up to the other half,
+	// which spreads retries while keeping a minimum wait.
+	JitterEqual Jitter = "equal"
+)
+
+// apply returns the wait for the backoff delay d. Unknown strategies wait exactly d, like JitterNone.
+func (j Jitter) apply(d time.Duration) time.Duration {
+	if d <= 0 {
+		return d
+	}
+
+	switch j {
+	case JitterFull:
+		return rand.N(d + 1)
+	case JitterEqual:
+		return d/2 + rand.N(d-d/2+1)
+	default:
+		return d
+	}
+}
+
+// do calls fn until it succeeds, the attempts are exhausted or ctx is done,
+// sleeping between attempts with exponential backoff. It returns the last error from fn.
+func (r RetryConfig) do(ctx context.Context, log Logger, fn func(context.Context) error) error {
+	return r.doIf(ctx, log, nil, fn)
+}
+
+// doIf behaves like do, but stops at the first error for which retryable returns false.
+// A nil retryable retries every error.
+func (r RetryConfig) doIf(ctx context.Context, log Logger, retryable func(error) bool, fn func(context.Context) error) error {
+	attempts := max(r.Attempts, 1)
+	delay := r.InitialDelay
+	if delay <= 0 {
+		delay = defaultRetryInitialDelay
+	}
+	maxDelay := r.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultRetryMaxDelay
+	}
+	multiplier := r.Multiplier
+	if multiplier < 1 {
+		multiplier = defaultRetryMultiplier
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn(ctx)
+		if err == nil || attempt >= attempts || (retryable != nil && !retryable(err)) {
+			return err
+		}
+
+		wait := r.Jitter.apply(min(delay, maxDelay))
+		log.Warnf("Attempt %d/%d failed: %v, retrying in %v", attempt, attempts, err, wait)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w; last error: %v", ctx.Err(), err)
+		case <-timer.C:
+		}
+
+		delay = time.Duration(float64(delay) * multiplier)
+	}
+}
@@ -0,0 +1,118 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryConfigSucceedsOnThirdAttempt(t *testing.T) {
+	calls := 0
+	retry := RetryConfig{Attempts: 5, InitialDelay: time.Millisecond}
+
+	err := retry.do(context.Background(), defaultLogger(), func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("not ready")
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryConfigReturnsLastError(t *testing.T) {
+	calls := 0
+	retry := RetryConfig{Attempts: 3, InitialDelay: time.Millisecond}
+
+	err := retry.do(context.Background(), defaultLogger(), func(context.Context) error {
+		calls++
+		return errors.New("attempt failed")
+	})
+
+	assert.EqualError(t, err, "attempt failed")
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryConfigZeroValueTriesOnce(t *testing.T) {
+	calls := 0
+
+	err := RetryConfig{}.do(context.Background(), defaultLogger(), func(context.Context) error {
+		calls++
+		return errors.New("down")
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryConfigStopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	retry := RetryConfig{Attempts: 10, InitialDelay: time.Hour}
+
+	err := retry.do(ctx, defaultLogger(), func(context.Context) error {
+		cancel()
+		return errors.New("down")
+	})
+
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestRetryConfigDoIfStopsOnPermanentError(t *testing.T) {
+	calls := 0
+	permanent := errors.New("permanent")
+	retry := RetryConfig{Attempts: 5, InitialDelay: time.Millisecond}
+
+	err := retry.doIf(context.Background(), defaultLogger(), func(err error) bool {
+		return !errors.Is(err, permanent)
+	}, func(context.Context) error {
+		calls++
+		if calls == 1 {
+			return errors.New("transient")
+		}
+		return permanent
+	})
+
+	assert.ErrorIs(t, err, permanent)
+	assert.Equal(t, 2, calls)
+}
+
+func TestJitterBounds(t *testing.T) {
+	delay := 100 * time.Millisecond
+
+	assert.Equal(t, delay, JitterNone.apply(delay))
+	assert.Equal(t, delay, Jitter("gaussian").apply(delay), "Expected unknown strategies to wait the full delay")
+
+	spread := map[time.Duration]bool{}
+	for range 100 {
+		full := JitterFull.apply(delay)
+		assert.GreaterOrEqual(t, full, time.Duration(0))
+		assert.LessOrEqual(t, full, delay)
+		spread[full] = true
+
+		equal := JitterEqual.apply(delay)
+		assert.GreaterOrEqual(t, equal, delay/2)
+		assert.LessOrEqual(t, equal, delay)
+	}
+	assert.Greater(t, len(spread), 1, "Expected full jitter to vary the wait")
+}
+
+func TestRetryConfigWithJitterRetries(t *testing.T) {
+	calls := 0
+	retry := RetryConfig{Attempts: 3, InitialDelay: time.Millisecond, Jitter: JitterFull}
+
+	err := retry.do(context.Background(), defaultLogger(), func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("down")
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
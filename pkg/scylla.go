@@ -0,0 +1,40 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gocql/gocql"
+)
+
+// NewScyllaConnection establishes a session with a ScyllaDB cluster reachable through the given hosts
+// and binds it to keyspace. Queries are routed to a replica owning the partition by a token-aware policy.
+// Timeouts and consistency default as for NewCassandraConnection.
+// If successful, it returns the session to interact with the cluster.
+// If any error occurs, it logs the error and terminates the application.
+func NewScyllaConnection(hosts []string, keyspace string, opts ...Option) *gocql.Session {
+	session, err := NewScyllaConnectionE(hosts, keyspace, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to ScyllaDB: %v", err.Error())
+	}
+
+	return session
+}
+
+// NewScyllaConnectionE establishes a session with a ScyllaDB cluster like NewScyllaConnection,
+// but returns an error instead of terminating the application.
+func NewScyllaConnectionE(hosts []string, keyspace string, opts ...Option) (*gocql.Session, error) {
+	o := newOptions(opts)
+	if len(hosts) == 0 {
+		return nil, o.failed(connectionError("scylladb", StageConfig, errors.New("no ScyllaDB hosts provided")))
+	}
+
+	cluster := gocql.NewCluster(hosts...)
+	cluster.Keyspace = keyspace
+	cluster.Timeout = defaultCassandraTimeout
+	cluster.ConnectTimeout = defaultCassandraTimeout
+	cluster.Consistency = gocql.Quorum
+	cluster.PoolConfig.HostSelectionPolicy = gocql.TokenAwareHostPolicy(gocql.RoundRobinHostPolicy())
+
+	return createCassandraSession(context.Background(), "ScyllaDB", "scylladb", cluster, o)
+}
@@ -0,0 +1,20 @@
+//go:build integration
+
+package pkg
+
+import (
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScyllaConnection(t *testing.T) {
+	session := NewScyllaConnection([]string{"localhost:9042"}, "system", WithConsistency(gocql.One))
+	defer session.Close()
+
+	assert.NotNil(t, session)
+
+	err := session.Query("SELECT release_version FROM local").Exec()
+	assert.NoError(t, err, "Expected no error when executing query on ScyllaDB")
+}
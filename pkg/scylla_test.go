@@ -0,0 +1,14 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScyllaConnectionENoHosts(t *testing.T) {
+	session, err := NewScyllaConnectionE(nil, "system")
+
+	assert.Nil(t, session)
+	assert.Error(t, err, "Expected an error when no hosts are provided")
+}
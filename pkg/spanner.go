@@ -0,0 +1,66 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+)
+
+// NewSpannerConnection creates a Cloud Spanner client for database, in the form
+// "projects/<project>/instances/<instance>/databases/<database>", and validates it by running SELECT 1.
+// Credentials are taken from the environment unless set with WithGoogleClientOptions;
+// pass WithEmulator to use a local Spanner emulator instead.
+// If any error occurs, it logs the error and terminates the application.
+func NewSpannerConnection(ctx context.Context, database string, opts ...Option) *spanner.Client {
+	client, err := NewSpannerConnectionE(ctx, database, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to Spanner: %v", err.Error())
+	}
+
+	return client
+}
+
+// NewSpannerConnectionE creates a Cloud Spanner client like NewSpannerConnection,
+// but returns an error instead of terminating the application.
+func NewSpannerConnectionE(ctx context.Context, database string, opts ...Option) (_ *spanner.Client, err error) {
+	o := newOptions(opts)
+
+	ctx, cancel := o.connectContext(ctx)
+	defer cancel()
+
+	ctx, span := o.startConnectSpan(ctx, "spanner", database)
+	defer func() {
+		endSpan(span, err)
+		o.finishConnect("spanner", database, err)
+	}()
+
+	o.Logger.Infof("Connecting to Spanner database %s", database)
+
+	cfg := spanner.ClientConfig{DisableNativeMetrics: o.google.emulatorHost != ""}
+	client, err := spanner.NewClientWithConfig(ctx, database, cfg, o.google.options()...)
+	if err != nil {
+		return nil, connectionError("spanner", StageOpen, fmt.Errorf("failed to create Spanner client: %w", err))
+	}
+
+	err = o.ping(ctx, func(ctx context.Context) error {
+		iter := client.Single().Query(ctx, spanner.Statement{SQL: "SELECT 1"})
+		defer iter.Stop()
+
+		_, err := iter.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		client.Close()
+		return nil, connectionError("spanner", StagePing, fmt.Errorf("failed to query Spanner: %w", err))
+	}
+
+	o.Logger.Infof("Successfully connected to Spanner")
+	o.track(client)
+
+	return client, nil
+}
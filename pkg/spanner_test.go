@@ -0,0 +1,35 @@
+package pkg
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSpannerConnection(t *testing.T) {
+	// Without a server the client waits for a session well past the connect timeout, so check the port first.
+	conn, err := net.DialTimeout("tcp", "localhost:9010", time.Second)
+	if err != nil {
+		t.Skipf("Spanner emulator is not reachable: %v", err)
+	}
+	conn.Close()
+
+	client, err := NewSpannerConnectionE(context.Background(), "projects/test-project/instances/test-instance/databases/testdb",
+		WithEmulator("localhost:9010"), WithConnectTimeout(2*time.Second))
+	if err != nil {
+		t.Skipf("Spanner emulator is not reachable: %v", err)
+	}
+	defer client.Close()
+
+	assert.NotNil(t, client)
+}
+
+func TestNewSpannerConnectionEInvalidDatabase(t *testing.T) {
+	client, err := NewSpannerConnectionE(context.Background(), "testdb", WithEmulator("localhost:9010"))
+
+	assert.Nil(t, client)
+	assert.ErrorContains(t, err, "failed to create Spanner client")
+}
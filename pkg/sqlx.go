@@ -0,0 +1,68 @@
+package pkg
+
+import (
+	"database/sql"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+// WrapSQLx wraps an already connected db in a *sqlx.DB. driverName selects the bind variable style,
+// e.g. "mysql", "postgres" or "sqlite3".
+func WrapSQLx(db *sql.DB, driverName string) *sqlx.DB {
+	return sqlx.NewDb(db, driverName)
+}
+
+// NewSQLxMySQLConnection establishes a connection to a MySQL database like NewSQLDBConnection
+// and returns it wrapped in a *sqlx.DB.
+// If any error occurs, it logs the error and terminates the application.
+func NewSQLxMySQLConnection[T string | mysql.Config](cfg T, opts ...Option) *sqlx.DB {
+	return WrapSQLx(NewSQLDBConnection(cfg, opts...), "mysql")
+}
+
+// NewSQLxMySQLConnectionE establishes a connection to a MySQL database like NewSQLxMySQLConnection,
+// but returns an error instead of terminating the application.
+func NewSQLxMySQLConnectionE[T string | mysql.Config](cfg T, opts ...Option) (*sqlx.DB, error) {
+	db, err := NewSQLDBConnectionE(cfg, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return WrapSQLx(db, "mysql"), nil
+}
+
+// NewSQLxPostgresConnection establishes a connection to a PostgreSQL database like NewPostgresDBConnection
+// and returns it wrapped in a *sqlx.DB.
+// If any error occurs, it logs the error and terminates the application.
+func NewSQLxPostgresConnection[T string | PostgresConfig](cfg T, opts ...Option) *sqlx.DB {
+	return WrapSQLx(NewPostgresDBConnection(cfg, opts...), "postgres")
+}
+
+// NewSQLxPostgresConnectionE establishes a connection to a PostgreSQL database like NewSQLxPostgresConnection,
+// but returns an error instead of terminating the application.
+func NewSQLxPostgresConnectionE[T string | PostgresConfig](cfg T, opts ...Option) (*sqlx.DB, error) {
+	db, err := NewPostgresDBConnectionE(cfg, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return WrapSQLx(db, "postgres"), nil
+}
+
+// NewSQLxSQLiteConnection opens a SQLite database like NewSQLiteConnectionWithConfig
+// and returns it wrapped in a *sqlx.DB.
+// If any error occurs, it logs the error and terminates the application.
+func NewSQLxSQLiteConnection(cfg SQLiteConfig, opts ...Option) *sqlx.DB {
+	return WrapSQLx(NewSQLiteConnectionWithConfig(cfg, opts...), cfg.driverName())
+}
+
+// NewSQLxSQLiteConnectionE opens a SQLite database like NewSQLxSQLiteConnection,
+// but returns an error instead of terminating the application.
+func NewSQLxSQLiteConnectionE(cfg SQLiteConfig, opts ...Option) (*sqlx.DB, error) {
+	db, err := NewSQLiteConnectionWithConfigE(cfg, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return WrapSQLx(db, cfg.driverName()), nil
+}
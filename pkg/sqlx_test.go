@@ -0,0 +1,38 @@
+package pkg
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSQLxMySQLConnection(t *testing.T) {
+	db := NewSQLxMySQLConnection("user:password@tcp(localhost:3306)/testdb")
+
+	assert.NoError(t, db.Ping())
+	assert.Equal(t, sqlx.QUESTION, sqlx.BindType(db.DriverName()))
+}
+
+func TestNewSQLxSQLiteConnectionE(t *testing.T) {
+	db, err := NewSQLxSQLiteConnectionE(SQLiteConfig{Path: filepath.Join(t.TempDir(), "sqlx.db")})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	db.MustExec("CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)")
+	db.MustExec(db.Rebind("INSERT INTO users (name) VALUES (?)"), "alice")
+
+	var user struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+	assert.NoError(t, db.Get(&user, "SELECT id, name FROM users"))
+	assert.Equal(t, "alice", user.Name)
+}
+
+func TestWrapSQLxBindType(t *testing.T) {
+	db := WrapSQLx(openTestSQLite(t), "postgres")
+
+	assert.Equal(t, "SELECT * FROM users WHERE id = $1", db.Rebind("SELECT * FROM users WHERE id = ?"))
+}
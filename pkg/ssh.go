@@ -0,0 +1,212 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// SSHTunnelConfig describes the SSH server, typically a bastion host, that database connections are tunneled through.
+// Set Password, PrivateKey or both, and either KnownHostsFile or HostKeyCallback to verify the server.
+type SSHTunnelConfig struct {
+	// Addr is the SSH server as host or host:port. The port defaults to 22.
+	Addr string `json:"addr" yaml:"addr"`
+	User string `json:"user" yaml:"user"`
+	// Password authenticates with the password method.
+	Password string `json:"password" yaml:"password"`
+	// PrivateKey is a PEM encoded private key, decrypted with PrivateKeyPassphrase if it is protected.
+	PrivateKey           []byte `json:"private_key" yaml:"private_key"`
+	PrivateKeyPassphrase string `json:"private_key_passphrase" yaml:"private_key_passphrase"`
+	// KnownHostsFile is the known_hosts file the host key of the server is checked against.
+	KnownHostsFile string `json:"known_hosts_file" yaml:"known_hosts_file"`
+	// HostKeyCallback verifies the host key of the server instead of KnownHostsFile.
+	HostKeyCallback ssh.HostKeyCallback `json:"-" yaml:"-"`
+}
+
+// WithSSHTunnel makes the SQL connectors dial the database through an SSH tunnel, so that databases in a private
+// network can be reached through a bastion host without a separate tunnel process. The address in the DSN is
+// resolved by the SSH server. The tunnel is opened before the ping and closed together with the *sql.DB; it is
+// not reopened if the SSH connection drops. It is supported by the MySQL, PostgreSQL and pgx based connectors.
+func WithSSHTunnel(cfg SSHTunnelConfig) Option {
+	return func(o *Options) {
+		o.sshTunnel = &cfg
+	}
+}
+
+// dialContextFunc is the dial function signature shared by the MySQL and pgx drivers.
+type dialContextFunc = func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// clientConfig returns the SSH client configuration for the authentication methods and host key check of c.
+func (c SSHTunnelConfig) clientConfig() (*ssh.ClientConfig, error) {
+	var auth []ssh.AuthMethod
+	if len(c.PrivateKey) > 0 {
+		var signer ssh.Signer
+		var err error
+		if c.PrivateKeyPassphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(c.PrivateKey, []byte(c.PrivateKeyPassphrase))
+		} else {
+			signer, err = ssh.ParsePrivateKey(c.PrivateKey)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid SSH private key: %w", err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if c.Password != "" {
+		auth = append(auth, ssh.Password(c.Password))
+	}
+	if len(auth) == 0 {
+		return nil, errors.New("no SSH password or private key configured")
+	}
+
+	hostKeyCallback := c.HostKeyCallback
+	if hostKeyCallback == nil {
+		if c.KnownHostsFile == "" {
+			return nil, errors.New("no SSH host key verification configured: set KnownHostsFile or HostKeyCallback")
+		}
+		var err error
+		if hostKeyCallback, err = knownhosts.New(c.KnownHostsFile); err != nil {
+			return nil, fmt.Errorf("failed to load SSH known hosts: %w", err)
+		}
+	}
+
+	return &ssh.ClientConfig{User: c.User, Auth: auth, HostKeyCallback: hostKeyCallback}, nil
+}
+
+// sshTunnel forwards connections through an SSH client.
+type sshTunnel struct {
+	client *ssh.Client
+}
+
+// openSSHTunnel connects and authenticates to the SSH server of cfg. The handshake is bounded by ctx.
+func openSSHTunnel(ctx context.Context, cfg SSHTunnelConfig) (*sshTunnel, error) {
+	clientConfig, err := cfg.clientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	addr := cfg.Addr
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "22")
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, addr, clientConfig)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	return &sshTunnel{client: ssh.NewClient(sshConn, chans, reqs)}, nil
+}
+
+// DialContext opens a connection to addr from the SSH server.
+func (t *sshTunnel) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	return t.client.DialContext(ctx, network, addr)
+}
+
+// Close closes the SSH connection and every connection forwarded through it.
+func (t *sshTunnel) Close() error {
+	return t.client.Close()
+}
+
+// tunnelConnector closes the SSH tunnel its connections go through when the *sql.DB built on it is closed.
+type tunnelConnector struct {
+	driver.Connector
+	tunnel *sshTunnel
+}
+
+// Close closes the wrapped connector, if it needs closing, and the tunnel.
+func (c tunnelConnector) Close() error {
+	var err error
+	if closer, ok := c.Connector.(io.Closer); ok {
+		err = closer.Close()
+	}
+	return errors.Join(err, c.tunnel.Close())
+}
+
+// openSQLConnector opens a *sql.DB on the connector built by newConnector. Without WithSSHTunnel dial is nil,
+// so the driver dials directly; otherwise the tunnel is opened first and dial goes through it.
+func (o *Options) openSQLConnector(ctx context.Context, system string, newConnector func(dial dialContextFunc) (driver.Connector, error)) (*sql.DB, error) {
+	if o.sshTunnel == nil {
+		connector, err := newConnector(nil)
+		if err != nil {
+			return nil, connectionError(system, StageConfig, err)
+		}
+		return sql.OpenDB(connector), nil
+	}
+
+	o.Logger.Infof("Opening SSH tunnel through %s", o.sshTunnel.Addr)
+	tunnel, err := openSSHTunnel(ctx, *o.sshTunnel)
+	if err != nil {
+		return nil, connectionError(system, StageOpen, fmt.Errorf("failed to open SSH tunnel through %s: %w", o.sshTunnel.Addr, err))
+	}
+
+	connector, err := newConnector(tunnel.DialContext)
+	if err != nil {
+		tunnel.Close()
+		return nil, connectionError(system, StageConfig, err)
+	}
+
+	return sql.OpenDB(tunnelConnector{Connector: connector, tunnel: tunnel}), nil
+}
+
+// driverConnector builds a connector for dsn on the MySQL or lib/pq driver that dials through dial.
+func driverConnector(driverName, dsn string, dial dialContextFunc) (driver.Connector, error) {
+	switch driverName {
+	case "mysql":
+		cfg, err := mysql.ParseDSN(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MySQL DSN: %w", err)
+		}
+		cfg.DialFunc = dial
+		return mysql.NewConnector(cfg)
+	case "postgres":
+		connector, err := pq.NewConnector(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PostgreSQL connection string: %w", err)
+		}
+		connector.Dialer(pqDialer{dial})
+		return connector, nil
+	default:
+		return nil, fmt.Errorf("SSH tunnels are not supported with the %s driver", driverName)
+	}
+}
+
+// pqDialer adapts a dial function to the lib/pq Dialer interfaces.
+type pqDialer struct {
+	dial dialContextFunc
+}
+
+func (d pqDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	return d.dial(ctx, network, addr)
+}
+
+func (d pqDialer) Dial(network, addr string) (net.Conn, error) {
+	return d.dial(context.Background(), network, addr)
+}
+
+func (d pqDialer) DialTimeout(network, addr string, timeout time.Duration) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return d.dial(ctx, network, addr)
+}
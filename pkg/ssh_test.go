@@ -0,0 +1,212 @@
+package pkg
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+// testSSHServer is an SSH server accepting the password "s3cret" that forwards direct-tcpip channels.
+type testSSHServer struct {
+	addr    string
+	hostKey ssh.PublicKey
+
+	mu        sync.Mutex
+	forwarded []string
+	closed    chan struct{}
+}
+
+func newTestSSHServer(t *testing.T) *testSSHServer {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(key)
+	assert.NoError(t, err)
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(_ ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if string(password) != "s3cret" {
+				return nil, errors.New("permission denied")
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	s := &testSSHServer{addr: ln.Addr().String(), hostKey: signer.PublicKey(), closed: make(chan struct{}, 1)}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn, config)
+		}
+	}()
+
+	return s
+}
+
+// serve handles one SSH connection and signals s.closed once the client disconnects.
+func (s *testSSHServer) serve(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		var target struct {
+			Host     string
+			Port     uint32
+			OrigHost string
+			OrigPort uint32
+		}
+		if newChannel.ChannelType() != "direct-tcpip" || ssh.Unmarshal(newChannel.ExtraData(), &target) != nil {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel")
+			continue
+		}
+
+		addr := net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port)))
+		s.mu.Lock()
+		s.forwarded = append(s.forwarded, addr)
+		s.mu.Unlock()
+
+		upstream, err := net.Dial("tcp", addr)
+		if err != nil {
+			_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		channel, channelReqs, err := newChannel.Accept()
+		if err != nil {
+			upstream.Close()
+			continue
+		}
+		go ssh.DiscardRequests(channelReqs)
+		go func() {
+			_, _ = io.Copy(channel, upstream)
+			channel.Close()
+		}()
+		go func() {
+			_, _ = io.Copy(upstream, channel)
+			upstream.Close()
+		}()
+	}
+
+	s.closed <- struct{}{}
+}
+
+func (s *testSSHServer) forwardedAddrs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.forwarded...)
+}
+
+func (s *testSSHServer) config() SSHTunnelConfig {
+	return SSHTunnelConfig{Addr: s.addr, User: "app", Password: "s3cret", HostKeyCallback: ssh.FixedHostKey(s.hostKey)}
+}
+
+// newClosingListener returns the address of a TCP listener that closes every connection right away.
+func newClosingListener(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	return ln.Addr().String()
+}
+
+func TestWithSSHTunnelDialsThroughServer(t *testing.T) {
+	tests := []struct {
+		name    string
+		connect func(addr string, opts ...Option) error
+	}{
+		{"MySQL", func(addr string, opts ...Option) error {
+			_, err := NewSQLDBConnectionE("app:pw@tcp("+addr+")/orders", opts...)
+			return err
+		}},
+		{"PostgreSQL", func(addr string, opts ...Option) error {
+			_, err := NewPostgresDBConnectionE("postgres://app:pw@"+addr+"/orders?sslmode=disable", opts...)
+			return err
+		}},
+		{"pgx", func(addr string, opts ...Option) error {
+			_, err := NewPostgresPgxConnectionCtx(t.Context(), "postgres://app:pw@"+addr+"/orders?sslmode=disable", opts...)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestSSHServer(t)
+			target := newClosingListener(t)
+
+			err := tt.connect(target, WithSSHTunnel(server.config()))
+
+			var connErr *ConnectionError
+			assert.ErrorAs(t, err, &connErr)
+			assert.Equal(t, StagePing, connErr.Stage, "Expected the database, not the tunnel, to fail")
+			assert.Contains(t, server.forwardedAddrs(), target)
+
+			select {
+			case <-server.closed:
+			case <-time.After(5 * time.Second):
+				t.Fatal("Expected the tunnel to be closed together with the database")
+			}
+		})
+	}
+}
+
+func TestWithSSHTunnelAuthenticationFails(t *testing.T) {
+	server := newTestSSHServer(t)
+	cfg := server.config()
+	cfg.Password = "wrong"
+
+	_, err := NewSQLDBConnectionE("app:pw@tcp(db.internal:3306)/orders", WithSSHTunnel(cfg))
+
+	assert.ErrorContains(t, err, "failed to open SSH tunnel")
+	var connErr *ConnectionError
+	assert.ErrorAs(t, err, &connErr)
+	assert.Equal(t, StageOpen, connErr.Stage)
+}
+
+func TestSSHTunnelConfigRequiresAuthAndHostKeyCheck(t *testing.T) {
+	_, err := SSHTunnelConfig{Addr: "bastion", KnownHostsFile: "known_hosts"}.clientConfig()
+	assert.ErrorContains(t, err, "no SSH password or private key")
+
+	_, err = SSHTunnelConfig{Addr: "bastion", Password: "s3cret"}.clientConfig()
+	assert.ErrorContains(t, err, "no SSH host key verification")
+}
+
+func TestWithSSHTunnelUnsupportedDriver(t *testing.T) {
+	server := newTestSSHServer(t)
+
+	_, err := NewSQLiteConnectionWithConfigE(SQLiteConfig{Path: SQLiteMemory}, WithSSHTunnel(server.config()))
+
+	assert.ErrorContains(t, err, "SSH tunnels are not supported")
+}
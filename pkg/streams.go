@@ -0,0 +1,26 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// EnsureRedisStreamGroup creates the consumer group on stream, creating the stream too if it doesn't exist yet.
+// A new group starts at the beginning of the stream, so entries written before it existed are delivered as well.
+// It succeeds if the group exists already, which makes it safe to call on every start of a service.
+func EnsureRedisStreamGroup(ctx context.Context, client redis.UniversalClient, stream, group string) error {
+	err := client.XGroupCreateMkStream(ctx, stream, group, "0").Err()
+	if err == nil || isBusyGroup(err) {
+		return nil
+	}
+
+	return fmt.Errorf("failed to create consumer group %q on Redis stream %q: %w", group, stream, err)
+}
+
+// isBusyGroup reports whether err is the BUSYGROUP error Redis returns for a consumer group that already exists.
+func isBusyGroup(err error) bool {
+	return strings.HasPrefix(err.Error(), "BUSYGROUP")
+}
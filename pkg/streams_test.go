@@ -0,0 +1,34 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnsureRedisStreamGroup(t *testing.T) {
+	client := NewRedisConnection("localhost:6379")
+	defer client.Close()
+
+	ctx := context.Background()
+	defer client.Del(ctx, "test-stream")
+
+	assert.NoError(t, EnsureRedisStreamGroup(ctx, client, "test-stream", "workers"))
+	assert.NoError(t, EnsureRedisStreamGroup(ctx, client, "test-stream", "workers"), "Expected an existing group to be accepted")
+}
+
+func TestEnsureRedisStreamGroupUnreachable(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	defer client.Close()
+
+	err := EnsureRedisStreamGroup(context.Background(), client, "events", "workers")
+	assert.ErrorContains(t, err, `failed to create consumer group "workers" on Redis stream "events"`)
+}
+
+func TestIsBusyGroup(t *testing.T) {
+	assert.True(t, isBusyGroup(errors.New("BUSYGROUP Consumer Group name already exists")))
+	assert.False(t, isBusyGroup(errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")))
+}
@@ -0,0 +1,308 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// defaultSupervisorRetry is used to wait for a lost Redis or MongoDB server when no retry policy is configured.
+var defaultSupervisorRetry = RetryConfig{Attempts: 5, InitialDelay: 100 * time.Millisecond, MaxDelay: 5 * time.Second}
+
+// supervisorKey marks the context of the supervisor's own pings so the hook does not supervise them.
+type supervisorKey struct{}
+
+// NewSupervisedRedisConnection establishes a connection to a Redis server like NewRedisConnection and
+// installs a hook that supervises the connection afterwards.
+//
+// When a command or pipeline fails with a connection error, such as a reset connection or a refused dial
+// after a failover, the supervisor pings the server with the backoff policy from WithRetry (five attempts
+// by default) until it answers, and then runs the command once more. The pool discards the broken
+// connections and dials new ones, so no new client is needed. If the server stays unreachable, the
+// original connection error is returned. Other errors, including redis.Nil and context errors, are
+// returned as is. Because a connection can drop after the server executed a command, a retried
+// non-idempotent command such as INCR may be applied twice.
+//
+// If any error occurs while connecting, it logs the error and terminates the application.
+func NewSupervisedRedisConnection[T string | *redis.Options | RedisConfig](cfg T, opts ...Option) *redis.Client {
+	client, err := NewSupervisedRedisConnectionE(cfg, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to Redis: %v", err.Error())
+	}
+
+	return client
+}
+
+// NewSupervisedRedisConnectionE establishes a supervised connection like NewSupervisedRedisConnection,
+// but returns an error instead of terminating the application when the initial ping fails.
+func NewSupervisedRedisConnectionE[T string | *redis.Options | RedisConfig](cfg T, opts ...Option) (*redis.Client, error) {
+	client, err := NewRedisConnectionE(cfg, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	o := newOptions(opts)
+	retry := o.Retry
+	if retry.Attempts == 0 {
+		retry = defaultSupervisorRetry
+	}
+
+	client.AddHook(&redisSupervisor{
+		retry: retry,
+		log:   o.Logger,
+		ping: func(ctx context.Context) error {
+			return client.Ping(context.WithValue(ctx, supervisorKey{}, true)).Err()
+		},
+	})
+
+	return client, nil
+}
+
+// redisSupervisor is the redis.Hook installed by NewSupervisedRedisConnection.
+type redisSupervisor struct {
+	retry RetryConfig
+	log   Logger
+	ping  func(context.Context) error
+	// mu lets a single goroutine wait for the server while the others queue behind it.
+	mu sync.Mutex
+}
+
+func (s *redisSupervisor) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (s *redisSupervisor) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		return s.supervise(ctx, func() error { return next(ctx, cmd) })
+	}
+}
+
+func (s *redisSupervisor) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		return s.supervise(ctx, func() error { return next(ctx, cmds) })
+	}
+}
+
+// supervise runs fn and, if it fails with a connection error, waits for the server and runs it once more.
+func (s *redisSupervisor) supervise(ctx context.Context, fn func() error) error {
+	err := fn()
+	if err == nil || ctx.Value(supervisorKey{}) != nil || !isConnError(err) {
+		return err
+	}
+
+	s.log.Warnf("Lost connection to Redis: %v, reconnecting", err)
+	if s.reconnect(ctx) != nil {
+		return err
+	}
+
+	s.log.Infof("Reconnected to Redis")
+	return fn()
+}
+
+// reconnect pings the server according to the retry policy until it answers.
+func (s *redisSupervisor) reconnect(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.retry.do(ctx, s.log, s.ping)
+}
+
+// isConnError reports whether err means the connection to the server was lost or could not be established.
+func isConnError(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && !opErr.Timeout()
+}
+
+const (
+	// defaultMongoSupervisorInterval is the time between health checks of a supervised MongoDB client.
+	defaultMongoSupervisorInterval = 10 * time.Second
+	// mongoSupervisorThreshold is the number of consecutive failed health checks after which
+	// a supervised MongoDB client is recreated.
+	mongoSupervisorThreshold = 3
+	// defaultMongoReconnectTimeout bounds each attempt to recreate a supervised MongoDB client
+	// when no connect timeout is configured.
+	defaultMongoReconnectTimeout = 30 * time.Second
+)
+
+// SupervisedMongoClient holds a MongoDB client that is recreated when it stays unhealthy,
+// see NewSupervisedMongoConnection. It is safe for concurrent use.
+type SupervisedMongoClient struct {
+	client   atomic.Pointer[mongo.Client]
+	connect  func(context.Context) (*mongo.Client, error)
+	interval time.Duration
+	retry    RetryConfig
+	log      Logger
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// NewSupervisedMongoConnection establishes a connection to a MongoDB server like NewMongoDBConnectionCtx and
+// supervises it in a background goroutine until Close is called.
+//
+// The driver reconnects on its own, but a client can stay unhealthy after a long outage such as an Atlas
+// maintenance window. The supervisor pings the server every interval, 10s if it is not positive, and after
+// three consecutive failures it creates a new client with the backoff policy from WithRetry (five attempts by
+// default) and swaps it in; the old client is disconnected. If the server stays unreachable, it tries again
+// on the next failed check. Call Client for every unit of work instead of keeping the returned client, so that
+// work started after a swap uses the new client. The context from WithContext bounds only the initial connection.
+//
+// If any error occurs while connecting, it logs the error and terminates the application.
+func NewSupervisedMongoConnection(ctx context.Context, connectionURI string, interval time.Duration, opts ...Option) *SupervisedMongoClient {
+	client, err := NewSupervisedMongoConnectionE(ctx, connectionURI, interval, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to MongoDB: %v", err.Error())
+	}
+
+	return client
+}
+
+// NewSupervisedMongoConnectionE establishes a supervised connection like NewSupervisedMongoConnection,
+// but returns an error instead of terminating the application when the initial connection fails.
+func NewSupervisedMongoConnectionE(ctx context.Context, connectionURI string, interval time.Duration, opts ...Option) (*SupervisedMongoClient, error) {
+	o := newOptions(opts)
+
+	// The clients are replaced over time, so only the supervised client is tracked in o.Connections.
+	connectOpts := *o
+	connectOpts.Connections = nil
+	client, err := connectMongo(ctx, connectionURI, &connectOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	retry := o.Retry
+	if retry.Attempts == 0 {
+		retry = defaultSupervisorRetry
+	}
+
+	s := startMongoSupervisor(client, mongoReconnect(connectionURI, connectOpts), interval, retry, o.Logger)
+	o.track(s)
+
+	return s, nil
+}
+
+// mongoReconnect returns the function a supervisor recreates its client with. Reconnects are retried by the
+// supervisor itself, so each attempt pings once. They outlive the startup, so the context from WithContext does
+// not apply to them; each attempt is bounded by the connect timeout instead, defaultMongoReconnectTimeout if unset.
+func mongoReconnect(connectionURI string, o Options) func(context.Context) (*mongo.Client, error) {
+	o.Retry = RetryConfig{}
+	o.Context = nil
+	if o.ConnectTimeout <= 0 {
+		o.ConnectTimeout = defaultMongoReconnectTimeout
+	}
+
+	return func(ctx context.Context) (*mongo.Client, error) {
+		return connectMongo(ctx, connectionURI, &o)
+	}
+}
+
+// startMongoSupervisor starts supervising client, which is recreated with connect.
+func startMongoSupervisor(client *mongo.Client, connect func(context.Context) (*mongo.Client, error), interval time.Duration, retry RetryConfig, log Logger) *SupervisedMongoClient {
+	if interval <= 0 {
+		interval = defaultMongoSupervisorInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &SupervisedMongoClient{
+		connect:  connect,
+		interval: interval,
+		retry:    retry,
+		log:      log,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	s.client.Store(client)
+
+	go s.supervise(ctx)
+
+	return s
+}
+
+// Client returns the current client.
+func (s *SupervisedMongoClient) Client() *mongo.Client {
+	return s.client.Load()
+}
+
+// Close stops the supervision and disconnects the current client. The context bounds the disconnect.
+func (s *SupervisedMongoClient) Close(ctx context.Context) error {
+	s.cancel()
+	<-s.done
+
+	return s.Client().Disconnect(ctx)
+}
+
+// supervise checks the client every interval until ctx is done and recreates it when it stays unhealthy.
+func (s *SupervisedMongoClient) supervise(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	failures := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		pingCtx, cancel := context.WithTimeout(ctx, s.interval)
+		err := s.Client().Ping(pingCtx, nil)
+		cancel()
+		if err == nil {
+			failures = 0
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		failures++
+		s.log.Warnf("MongoDB health check failed (%d/%d): %v", failures, mongoSupervisorThreshold, err)
+		if failures < mongoSupervisorThreshold {
+			continue
+		}
+
+		if s.recreate(ctx) {
+			failures = 0
+		}
+	}
+}
+
+// recreate connects a new client according to the retry policy and swaps it in. It reports whether it succeeded.
+func (s *SupervisedMongoClient) recreate(ctx context.Context) bool {
+	s.log.Warnf("Recreating the MongoDB client")
+
+	var fresh *mongo.Client
+	err := s.retry.do(ctx, s.log, func(ctx context.Context) error {
+		var err error
+		fresh, err = s.connect(ctx)
+		return err
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			s.log.Errorf("Failed to recreate the MongoDB client: %v", err)
+		}
+		return false
+	}
+
+	old := s.client.Swap(fresh)
+	disconnectCtx, cancel := context.WithTimeout(ctx, s.interval)
+	defer cancel()
+	_ = old.Disconnect(disconnectCtx)
+
+	s.log.Infof("Recreated the MongoDB client")
+	return true
+}
@@ -0,0 +1,154 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+func TestNewSupervisedRedisConnection(t *testing.T) {
+	client := NewSupervisedRedisConnection("localhost:6379")
+	defer client.Close()
+
+	assert.NoError(t, client.Ping(context.Background()).Err())
+}
+
+func newTestSupervisor(ping func(context.Context) error) *redisSupervisor {
+	return &redisSupervisor{
+		retry: RetryConfig{Attempts: 3, InitialDelay: time.Millisecond},
+		log:   defaultLogger(),
+		ping:  ping,
+	}
+}
+
+func TestRedisSupervisorRetriesAfterReconnect(t *testing.T) {
+	pings := 0
+	s := newTestSupervisor(func(context.Context) error {
+		pings++
+		if pings < 2 {
+			return syscall.ECONNREFUSED
+		}
+		return nil
+	})
+
+	calls := 0
+	process := s.ProcessHook(func(context.Context, redis.Cmder) error {
+		calls++
+		if calls == 1 {
+			return io.EOF
+		}
+		return nil
+	})
+
+	assert.NoError(t, process(context.Background(), redis.NewStatusCmd(context.Background(), "ping")))
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 2, pings)
+}
+
+func TestRedisSupervisorReturnsOriginalErrorWhenServerStaysDown(t *testing.T) {
+	s := newTestSupervisor(func(context.Context) error { return syscall.ECONNREFUSED })
+
+	calls := 0
+	process := s.ProcessPipelineHook(func(context.Context, []redis.Cmder) error {
+		calls++
+		return io.EOF
+	})
+
+	assert.ErrorIs(t, process(context.Background(), nil), io.EOF)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRedisSupervisorIgnoresOtherErrors(t *testing.T) {
+	s := newTestSupervisor(func(context.Context) error {
+		t.Fatal("Expected no reconnect for a non-connection error")
+		return nil
+	})
+
+	process := s.ProcessHook(func(context.Context, redis.Cmder) error { return redis.Nil })
+
+	assert.ErrorIs(t, process(context.Background(), nil), redis.Nil)
+}
+
+func TestIsConnError(t *testing.T) {
+	assert.True(t, isConnError(io.EOF))
+	assert.True(t, isConnError(&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}))
+	assert.False(t, isConnError(redis.Nil))
+	assert.False(t, isConnError(context.DeadlineExceeded))
+	assert.False(t, isConnError(errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")))
+}
+
+func TestNewSupervisedMongoConnection(t *testing.T) {
+	client := NewSupervisedMongoConnection(context.Background(), "mongodb://localhost:27017", time.Second)
+	defer client.Close(context.Background())
+
+	assert.NoError(t, client.Client().Ping(context.Background(), nil))
+}
+
+// newUnreachableMongoClient returns a client whose pings fail quickly, like during an outage.
+func newUnreachableMongoClient(t *testing.T) *mongo.Client {
+	t.Helper()
+
+	client, err := mongo.Connect(options.Client().ApplyURI("mongodb://127.0.0.1:1").SetServerSelectionTimeout(5 * time.Millisecond))
+	require.NoError(t, err)
+	return client
+}
+
+func TestMongoSupervisorRecreatesUnhealthyClient(t *testing.T) {
+	initial := newUnreachableMongoClient(t)
+	replacement := newUnreachableMongoClient(t)
+	connects := make(chan struct{}, 10)
+
+	s := startMongoSupervisor(initial, func(context.Context) (*mongo.Client, error) {
+		connects <- struct{}{}
+		return replacement, nil
+	}, 20*time.Millisecond, RetryConfig{Attempts: 2, InitialDelay: time.Millisecond}, defaultLogger())
+	defer s.Close(context.Background())
+
+	select {
+	case <-connects:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the supervisor to recreate the client")
+	}
+	assert.Eventually(t, func() bool { return s.Client() == replacement }, time.Second, 5*time.Millisecond)
+}
+
+func TestMongoSupervisorKeepsClientWhenReconnectFails(t *testing.T) {
+	initial := newUnreachableMongoClient(t)
+	attempts := make(chan struct{}, 100)
+
+	s := startMongoSupervisor(initial, func(context.Context) (*mongo.Client, error) {
+		attempts <- struct{}{}
+		return nil, errors.New("connection refused")
+	}, 20*time.Millisecond, RetryConfig{Attempts: 2, InitialDelay: time.Millisecond}, defaultLogger())
+
+	select {
+	case <-attempts:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the supervisor to try to recreate the client")
+	}
+	assert.NoError(t, s.Close(context.Background()))
+	assert.Same(t, initial, s.Client())
+}
+
+func TestMongoReconnectIgnoresStartupContext(t *testing.T) {
+	startup, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	reconnect := mongoReconnect("mongodb://127.0.0.1:1/?serverSelectionTimeoutMS=20",
+		*newOptions([]Option{WithContext(startup), WithConnectTimeout(time.Second)}))
+	client, err := reconnect(context.Background())
+
+	assert.Nil(t, client)
+	assert.ErrorContains(t, err, "failed to ping mongodb")
+	assert.NotErrorIs(t, err, context.Canceled, "Expected the reconnect to outlive the startup context")
+}
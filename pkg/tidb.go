@@ -0,0 +1,103 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	// defaultTiDBAddr is the address of a local TiDB server, which listens on port 4000 rather than 3306.
+	defaultTiDBAddr = "127.0.0.1:4000"
+	// defaultTiDBCollation is the default collation of TiDB servers with the new collation framework.
+	defaultTiDBCollation = "utf8mb4_bin"
+	// defaultTiDBTxnMode selects pessimistic transactions, which lock rows like MySQL does. TiDB releases
+	// before 3.0.8 default to optimistic transactions, which only detect conflicts at commit.
+	defaultTiDBTxnMode = "'pessimistic'"
+)
+
+// WithTiDBCheck makes NewTiDBConnection verify that the server is TiDB by querying @@tidb_version after the ping,
+// failing if the server is a plain MySQL server instead.
+func WithTiDBCheck() Option {
+	return func(o *Options) {
+		o.checkTiDB = true
+	}
+}
+
+// NewTiDBConnection establishes a connection to a TiDB database through the MySQL driver.
+// It accepts either a connection string or a MySQL config object. Unless they are set already,
+// the collation defaults to utf8mb4_bin and tidb_txn_mode to pessimistic, so transactions behave alike
+// across TiDB versions, and a mysql.Config without Addr connects to 127.0.0.1:4000.
+// TiDB does not support savepoints before v6.2, so nested transactions should not rely on them.
+// Pass WithTiDBCheck to also verify the server is TiDB.
+// If any error occurs, it logs the error and terminates the application.
+func NewTiDBConnection[T string | mysql.Config](cfg T, opts ...Option) *sql.DB {
+	db, err := NewTiDBConnectionE(cfg, opts...)
+	if err != nil {
+		fatalf(newOptions(opts).Logger, "Failed to connect to the TiDB database: %v", err.Error())
+	}
+
+	return db
+}
+
+// NewTiDBConnectionE establishes a connection to a TiDB database like NewTiDBConnection,
+// but returns an error instead of terminating the application.
+func NewTiDBConnectionE[T string | mysql.Config](cfg T, opts ...Option) (*sql.DB, error) {
+	o := newOptions(opts)
+
+	var mysqlCfg *mysql.Config
+
+	switch v := any(cfg).(type) {
+	case string:
+		parsed, err := mysql.ParseDSN(v)
+		if err != nil {
+			return nil, o.failed(redactErr(connectionError("tidb", StageConfig, fmt.Errorf("invalid TiDB DSN: %w", err)), v))
+		}
+		mysqlCfg = parsed
+	case mysql.Config:
+		mysqlCfg = v.Clone()
+		o.mysqlTimeouts.apply(mysqlCfg)
+		applyMySQLProgramName(mysqlCfg)
+		if mysqlCfg.Addr == "" && (mysqlCfg.Net == "" || mysqlCfg.Net == "tcp") {
+			mysqlCfg.Net, mysqlCfg.Addr = "tcp", defaultTiDBAddr
+		}
+	default:
+		return nil, fmt.Errorf("invalid config type: %T", v)
+	}
+
+	applyTiDBDefaults(mysqlCfg)
+	if o.checkTiDB {
+		o.verifySQL = checkTiDBVersion
+	}
+
+	return openSQL(context.Background(), "TiDB", "tidb", "mysql", mysqlCfg.FormatDSN(), PoolConfig{}, o)
+}
+
+// applyTiDBDefaults sets the TiDB collation and tidb_txn_mode on cfg unless they are set already.
+func applyTiDBDefaults(cfg *mysql.Config) {
+	if cfg.Collation == "" {
+		cfg.Collation = defaultTiDBCollation
+	}
+	if _, ok := cfg.Params["tidb_txn_mode"]; !ok {
+		if cfg.Params == nil {
+			cfg.Params = map[string]string{}
+		}
+		cfg.Params["tidb_txn_mode"] = defaultTiDBTxnMode
+	}
+}
+
+// checkTiDBVersion returns an error if the server behind db does not report a TiDB version.
+func checkTiDBVersion(ctx context.Context, db *sql.DB) error {
+	var version string
+	if err := db.QueryRowContext(ctx, "SELECT @@tidb_version").Scan(&version); err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1193 {
+			return errors.New("server is not TiDB: @@tidb_version is unknown")
+		}
+		return fmt.Errorf("failed to query TiDB version: %w", err)
+	}
+	return nil
+}
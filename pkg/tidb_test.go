@@ -0,0 +1,73 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTiDBConnection(t *testing.T) {
+	db := NewTiDBConnection("root:@tcp(localhost:4000)/test", WithTiDBCheck())
+	defer db.Close()
+
+	assert.NoError(t, db.Ping())
+}
+
+func TestApplyTiDBDefaults(t *testing.T) {
+	cfg := mysql.NewConfig()
+	applyTiDBDefaults(cfg)
+
+	assert.Equal(t, defaultTiDBCollation, cfg.Collation)
+	assert.Equal(t, defaultTiDBTxnMode, cfg.Params["tidb_txn_mode"])
+
+	custom := mysql.NewConfig()
+	custom.Collation = "utf8mb4_general_ci"
+	custom.Params = map[string]string{"tidb_txn_mode": "'optimistic'"}
+	applyTiDBDefaults(custom)
+
+	assert.Equal(t, "utf8mb4_general_ci", custom.Collation)
+	assert.Equal(t, "'optimistic'", custom.Params["tidb_txn_mode"])
+}
+
+func TestNewTiDBConnectionEDefaultsAddr(t *testing.T) {
+	var host string
+	db, err := NewTiDBConnectionE(mysql.Config{User: "root", DBName: "test"}, WithoutPing(),
+		WithOnConnect(func(_, h string) { host = h }))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	assert.Equal(t, defaultTiDBAddr, host)
+}
+
+func TestNewTiDBConnectionEMalformedDSN(t *testing.T) {
+	db, err := NewTiDBConnectionE("root:s3cret@tcp(localhost:4000)test")
+
+	assert.Nil(t, db)
+	assert.ErrorContains(t, err, "invalid TiDB DSN")
+	assert.NotContains(t, err.Error(), "s3cret")
+}
+
+func TestPingSQLVerifyFailureIsNotRegistered(t *testing.T) {
+	var conns Connections
+	var connected bool
+	var stage Stage
+	notTiDB := errors.New("server is not TiDB")
+
+	db, err := NewSQLiteConnectionWithConfigE(SQLiteConfig{Path: SQLiteMemory},
+		WithName("verify-failure"), WithConnections(&conns),
+		WithOnConnect(func(string, string) { connected = true }),
+		WithOnError(func(s Stage, _ error) { stage = s }),
+		func(o *Options) { o.verifySQL = func(context.Context, *sql.DB) error { return notTiDB } })
+
+	assert.Nil(t, db)
+	assert.ErrorIs(t, err, notTiDB)
+	assert.False(t, connected, "Expected OnConnect not to fire for a failed verification")
+	assert.Equal(t, StageVerify, stage)
+	_, ok := Get("verify-failure")
+	assert.False(t, ok, "Expected the closed db not to be registered")
+	assert.Empty(t, conns.closers, "Expected the closed db not to be tracked")
+}
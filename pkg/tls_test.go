@@ -0,0 +1,92 @@
+package pkg
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTestCertificate writes a self-signed certificate and its key to dir and returns their paths.
+func writeTestCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+
+	return certFile, keyFile
+}
+
+func TestLoadTLSConfig(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+
+	cfg, err := LoadTLSConfig(certFile, certFile, keyFile)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, cfg.RootCAs)
+	assert.Len(t, cfg.Certificates, 1)
+}
+
+func TestLoadTLSConfigRequiresCertAndKeyTogether(t *testing.T) {
+	certFile, _ := writeTestCertificate(t, t.TempDir())
+
+	_, err := LoadTLSConfig("", certFile, "")
+
+	assert.Error(t, err)
+}
+
+func TestRegisterMySQLTLSUsesUniqueNames(t *testing.T) {
+	var first, second mysql.Config
+
+	firstName, err := registerMySQLTLS(&first, &tls.Config{})
+	assert.NoError(t, err)
+	secondName, err := registerMySQLTLS(&second, &tls.Config{})
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, firstName, secondName)
+	assert.Equal(t, firstName, first.TLSConfig)
+	assert.Equal(t, secondName, second.TLSConfig)
+
+	mysql.DeregisterTLSConfig(firstName)
+	mysql.DeregisterTLSConfig(secondName)
+}
@@ -0,0 +1,45 @@
+package pkg
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the spans emitted by this package.
+const tracerName = "github.com/cherry-blossom1/go-database-connection/pkg"
+
+// WithTracerProvider emits the connector's spans through tp instead of the global OpenTelemetry provider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *Options) {
+		o.TracerProvider = tp
+	}
+}
+
+// startConnectSpan starts the "db.connect" span that covers connecting to and pinging a database.
+func (o *Options) startConnectSpan(ctx context.Context, system, host string) (context.Context, trace.Span) {
+	tp := o.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+
+	return tp.Tracer(tracerName).Start(ctx, "db.connect",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", system),
+			attribute.String("server.address", host),
+		),
+	)
+}
+
+// endSpan records err on span, if any, and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
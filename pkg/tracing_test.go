@@ -0,0 +1,24 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestConnectSpanIsRecorded(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	db := NewSQLiteConnection("", SQLiteMemory, WithTracerProvider(tp))
+	defer db.Close()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "db.connect", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("db.system", "sqlite"))
+	assert.Contains(t, spans[0].Attributes(), attribute.String("server.address", ":memory:"))
+}
@@ -0,0 +1,98 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	// sqlStateDeadlockDetected is the SQLSTATE PostgreSQL returns for the victim of a deadlock.
+	sqlStateDeadlockDetected = "40P01"
+	// mysqlErrDeadlock is ER_LOCK_DEADLOCK, returned by MySQL and MariaDB for the victim of a deadlock.
+	mysqlErrDeadlock = 1213
+)
+
+// TxOption configures a transaction run by WithTx.
+type TxOption func(*txSettings)
+
+// txSettings holds the settings configured through the TxOption functions.
+type txSettings struct {
+	txOptions *sql.TxOptions
+	retry     RetryConfig
+	retryable func(error) bool
+}
+
+// WithTxOptions sets the isolation level and read-only flag the transaction is started with.
+func WithTxOptions(opts *sql.TxOptions) TxOption {
+	return func(s *txSettings) {
+		s.txOptions = opts
+	}
+}
+
+// WithTxRetry reruns the whole transaction with backoff according to retry when it fails with an error
+// reported by IsRetryableTxError. Other errors are returned immediately.
+func WithTxRetry(retry RetryConfig) TxOption {
+	return func(s *txSettings) {
+		s.retry = retry
+	}
+}
+
+// WithTxRetryIf replaces IsRetryableTxError as the check deciding which errors WithTxRetry retries.
+func WithTxRetryIf(retryable func(error) bool) TxOption {
+	return func(s *txSettings) {
+		s.retryable = retryable
+	}
+}
+
+// IsRetryableTxError reports whether err aborted a transaction that can succeed when run again from the start:
+// a serialization failure (SQLSTATE 40001) or a deadlock (SQLSTATE 40P01) on PostgreSQL and CockroachDB,
+// or a deadlock (error 1213) on MySQL and MariaDB.
+func IsRetryableTxError(err error) bool {
+	var state interface{ SQLState() string }
+	if errors.As(err, &state) {
+		return state.SQLState() == sqlStateSerializationFailure || state.SQLState() == sqlStateDeadlockDetected
+	}
+
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDeadlock
+}
+
+// WithTx runs fn inside a transaction on db and commits it. The transaction is rolled back if fn returns
+// an error or panics, in which case the panic is propagated after the rollback. By default the transaction
+// is attempted once; pass WithTxRetry to retry deadlocks and serialization failures. fn may run several
+// times then, so it should not have side effects outside the transaction.
+func WithTx(ctx context.Context, db SQLDB, fn func(*sql.Tx) error, opts ...TxOption) error {
+	s := txSettings{retryable: IsRetryableTxError}
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	return s.retry.doIf(ctx, defaultLogger(), s.retryable, func(ctx context.Context) error {
+		return runTx(ctx, db, s.txOptions, fn)
+	})
+}
+
+// runTx makes a single attempt at running fn inside a transaction.
+func runTx(ctx context.Context, db SQLDB, txOptions *sql.TxOptions, fn func(*sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, txOptions)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
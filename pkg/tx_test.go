@@ -0,0 +1,125 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+// openTxTestDB returns an in-memory SQLite database with an empty "items" table, limited to one connection
+// so that every transaction sees the same database.
+func openTxTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db := openTestSQLite(t)
+	db.SetMaxOpenConns(1)
+	_, err := db.Exec("CREATE TABLE items (name TEXT)")
+	assert.NoError(t, err)
+
+	return db
+}
+
+func countItems(t *testing.T, db *sql.DB) int {
+	t.Helper()
+
+	var n int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM items").Scan(&n))
+	return n
+}
+
+func TestWithTxCommits(t *testing.T) {
+	db := openTxTestDB(t)
+
+	err := WithTx(context.Background(), db, func(tx *sql.Tx) error {
+		_, err := tx.Exec("INSERT INTO items VALUES ('a')")
+		return err
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, countItems(t, db))
+}
+
+func TestWithTxRollsBackOnError(t *testing.T) {
+	db := openTxTestDB(t)
+	boom := errors.New("boom")
+
+	err := WithTx(context.Background(), db, func(tx *sql.Tx) error {
+		if _, err := tx.Exec("INSERT INTO items VALUES ('a')"); err != nil {
+			return err
+		}
+		return boom
+	}, WithTxRetry(RetryConfig{Attempts: 3, InitialDelay: time.Millisecond}))
+
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, 0, countItems(t, db))
+}
+
+func TestWithTxRollsBackOnPanic(t *testing.T) {
+	db := openTxTestDB(t)
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = WithTx(context.Background(), db, func(tx *sql.Tx) error {
+			_, _ = tx.Exec("INSERT INTO items VALUES ('a')")
+			panic("boom")
+		})
+	})
+	assert.Equal(t, 0, countItems(t, db))
+}
+
+func TestWithTxRetriesDeadlocks(t *testing.T) {
+	db := openTxTestDB(t)
+
+	calls := 0
+	err := WithTx(context.Background(), db, func(tx *sql.Tx) error {
+		calls++
+		if _, err := tx.Exec("INSERT INTO items VALUES ('a')"); err != nil {
+			return err
+		}
+		if calls < 3 {
+			return &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+		}
+		return nil
+	}, WithTxRetry(RetryConfig{Attempts: 3, InitialDelay: time.Millisecond}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 1, countItems(t, db), "Expected the failed attempts to be rolled back")
+}
+
+func TestWithTxWithoutRetryMakesOneAttempt(t *testing.T) {
+	db := openTxTestDB(t)
+
+	calls := 0
+	err := WithTx(context.Background(), db, func(*sql.Tx) error {
+		calls++
+		return &pgconn.PgError{Code: "40001"}
+	})
+
+	assert.True(t, IsSerializationFailure(err))
+	assert.Equal(t, 1, calls)
+}
+
+func TestIsRetryableTxError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&pgconn.PgError{Code: "40001"}, true},
+		{fmt.Errorf("commit: %w", &pgconn.PgError{Code: "40P01"}), true},
+		{&pgconn.PgError{Code: "23505"}, false},
+		{&mysql.MySQLError{Number: 1213}, true},
+		{&mysql.MySQLError{Number: 1062}, false},
+		{sql.ErrTxDone, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, IsRetryableTxError(tt.err), "%v", tt.err)
+	}
+}
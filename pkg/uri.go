@@ -0,0 +1,171 @@
+package pkg
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// defaultPorts holds the port assumed for a URI without one, per backend.
+var defaultPorts = map[Kind]int{
+	KindMySQL:    3306,
+	KindPostgres: 5432,
+	KindMongoDB:  27017,
+	KindRedis:    6379,
+}
+
+// ParsedConnection is a connection URI decomposed by ParseURI.
+type ParsedConnection struct {
+	// Driver is the backend selected by the scheme, as in Connection.Kind.
+	Driver Kind
+	// Scheme is the scheme as written in the URI, e.g. "rediss" or "mongodb+srv".
+	Scheme string
+	// Host and Port describe the first server. Port is the default port of the backend if the URI has none,
+	// except for mongodb+srv URIs, which name a DNS record rather than a server.
+	Host string
+	Port int
+	// Hosts lists every server as host:port, including the seed list of MongoDB URIs.
+	Hosts    []string
+	User     string
+	Password string
+	// Database is the database name, the numeric database for Redis, or the file path for SQLite.
+	Database string
+	// Params holds the query parameters. Only the first value of a repeated parameter is kept.
+	Params map[string]string
+
+	uri string
+}
+
+// String returns the URI with its password masked, for log messages.
+func (c *ParsedConnection) String() string {
+	return redactDSN(c.uri)
+}
+
+// ParseURI decomposes uri into its parts without connecting. It accepts the schemes supported by Connect;
+// other schemes are an error wrapping ErrInvalidScheme. Credentials are masked in the returned error.
+func ParseURI(uri string) (*ParsedConnection, error) {
+	scheme, rest, ok := strings.Cut(uri, ":")
+	if !ok {
+		return nil, redactErr(fmt.Errorf("missing scheme in connection URI %q", uri), uri)
+	}
+
+	c := &ParsedConnection{Scheme: scheme, Params: map[string]string{}, uri: uri}
+
+	switch strings.ToLower(scheme) {
+	case "mysql":
+		c.Driver = KindMySQL
+	case "postgres", "postgresql":
+		c.Driver = KindPostgres
+	case "mongodb", "mongodb+srv":
+		c.Driver = KindMongoDB
+	case "redis", "rediss":
+		c.Driver = KindRedis
+	case "sqlite", "file":
+		c.Driver = KindSQLite
+		rest, _, _ = strings.Cut(rest, "#")
+		path, query, _ := strings.Cut(strings.TrimPrefix(rest, "//"), "?")
+		c.Database = path
+		values, err := url.ParseQuery(query)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SQLite URI parameters: %w", err)
+		}
+		c.setParams(values)
+		return c, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported connection URI scheme %q", ErrInvalidScheme, scheme)
+	}
+
+	// The host list is cut out before parsing, since net/url rejects MongoDB seed lists with ports.
+	host, withoutHost := cutHosts(uri)
+	u, err := url.Parse(withoutHost)
+	if err != nil {
+		return nil, redactErr(fmt.Errorf("invalid connection URI: %w", err), uri)
+	}
+
+	if u.User != nil {
+		c.User = u.User.Username()
+		c.Password, _ = u.User.Password()
+	}
+	c.Database = strings.TrimPrefix(u.Path, "/")
+	c.setParams(u.Query())
+
+	if host == "" && c.Driver == KindPostgres {
+		// PostgreSQL URLs name a Unix socket directory with a host parameter instead.
+		host = c.Params["host"]
+		if port := c.Params["port"]; port != "" {
+			host = net.JoinHostPort(host, port)
+		}
+	}
+
+	defaultPort := defaultPorts[c.Driver]
+	if strings.ToLower(scheme) == "mongodb+srv" {
+		defaultPort = 0
+	}
+	for _, h := range strings.Split(host, ",") {
+		if h == "" {
+			continue
+		}
+		name, port, err := splitHostPort(h, defaultPort)
+		if err != nil {
+			return nil, redactErr(fmt.Errorf("invalid host %q in connection URI: %w", h, err), uri)
+		}
+		if c.Hosts == nil {
+			c.Host, c.Port = name, port
+		}
+		if port != 0 {
+			h = net.JoinHostPort(name, strconv.Itoa(port))
+		}
+		c.Hosts = append(c.Hosts, h)
+	}
+
+	return c, nil
+}
+
+// cutHosts returns the host list in the authority of uri, and uri without it.
+func cutHosts(uri string) (hosts, rest string) {
+	start := strings.Index(uri, "://")
+	if start < 0 {
+		return "", uri
+	}
+	start += len("://")
+	end := len(uri)
+	if i := strings.IndexAny(uri[start:], "/?"); i >= 0 {
+		end = start + i
+	}
+	if at := strings.LastIndex(uri[start:end], "@"); at >= 0 {
+		start += at + 1
+	}
+
+	return uri[start:end], uri[:start] + uri[end:]
+}
+
+// setParams copies the first value of every parameter in values into c.Params.
+func (c *ParsedConnection) setParams(values url.Values) {
+	for key, value := range values {
+		c.Params[key] = value[0]
+	}
+}
+
+// splitHostPort splits hostport into the host and its numeric port, using defaultPort if it has none.
+func splitHostPort(hostport string, defaultPort int) (string, int, error) {
+	if i := strings.LastIndex(hostport, ":"); i < 0 || i < strings.LastIndex(hostport, "]") {
+		return strings.Trim(hostport, "[]"), defaultPort, nil
+	}
+
+	host, portStr, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return "", 0, err
+	}
+	if portStr == "" {
+		return host, defaultPort, nil
+	}
+
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid port %q", portStr)
+	}
+
+	return host, port, nil
+}
@@ -0,0 +1,69 @@
+package pkg
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// MySQLVersion returns the version reported by SELECT VERSION(), such as "8.4.2" or "11.4.2-MariaDB".
+// Call it right after connecting to log which server the application ended up on.
+func MySQLVersion(ctx context.Context, db SQLDB) (string, error) {
+	return sqlVersion(ctx, db, "SELECT VERSION()")
+}
+
+// PostgresVersion returns the version reported by SHOW server_version, such as "16.4".
+func PostgresVersion(ctx context.Context, db SQLDB) (string, error) {
+	return sqlVersion(ctx, db, "SHOW server_version")
+}
+
+// sqlVersion runs a query returning the server version as its single value.
+func sqlVersion(ctx context.Context, db SQLDB, query string) (string, error) {
+	var version string
+	if err := db.QueryRowContext(ctx, query).Scan(&version); err != nil {
+		return "", fmt.Errorf("failed to query server version: %w", err)
+	}
+	return version, nil
+}
+
+// MongoVersion returns the version reported by the buildInfo command, such as "7.0.12".
+func MongoVersion(ctx context.Context, client MongoClient) (string, error) {
+	var info struct {
+		Version string `bson:"version"`
+	}
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "buildInfo", Value: 1}}).Decode(&info); err != nil {
+		return "", fmt.Errorf("failed to query server version: %w", err)
+	}
+	return info.Version, nil
+}
+
+// RedisVersion returns the redis_version field of INFO server, such as "7.4.0".
+// For cluster clients the answer comes from whichever node serves the command.
+func RedisVersion(ctx context.Context, client redis.UniversalClient) (string, error) {
+	info, err := client.Info(ctx, "server").Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to query server version: %w", err)
+	}
+
+	version, ok := redisInfoField(info, "redis_version")
+	if !ok {
+		return "", errors.New("INFO server did not report redis_version")
+	}
+	return version, nil
+}
+
+// redisInfoField returns the value of field in the "name:value" lines of an INFO reply.
+func redisInfoField(info, field string) (string, bool) {
+	scanner := bufio.NewScanner(strings.NewReader(info))
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), field+":"); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
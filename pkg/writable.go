@@ -0,0 +1,88 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// ErrReadOnly is reported by connectors configured with WithVerifyWritable when the server only accepts reads.
+var ErrReadOnly = errors.New("server is read-only")
+
+// WithVerifyWritable makes the MySQL, MariaDB, PostgreSQL and MongoDB connectors check after the ping that the
+// server accepts writes, and fail with ErrReadOnly if it is a read replica, a hot standby or a secondary.
+// PostgreSQL is checked with SHOW transaction_read_only, MySQL and MariaDB with @@global.read_only, and
+// MongoDB with the hello command, or isMaster on servers that predate it. Other connectors ignore it, and it has no effect together with WithoutPing.
+func WithVerifyWritable() Option {
+	return func(o *Options) {
+		o.verifyWritable = true
+	}
+}
+
+// verifySQLWritable returns an error wrapping ErrReadOnly if the server behind db does not accept writes.
+// Systems without a known check are assumed to be writable.
+func verifySQLWritable(ctx context.Context, system string, db *sql.DB) error {
+	var readOnly bool
+
+	switch system {
+	case "postgresql":
+		var value string
+		if err := db.QueryRowContext(ctx, "SHOW transaction_read_only").Scan(&value); err != nil {
+			return fmt.Errorf("failed to query transaction_read_only: %w", err)
+		}
+		readOnly = value == "on"
+	case "mysql", "mariadb":
+		var value int
+		if err := db.QueryRowContext(ctx, "SELECT @@global.read_only").Scan(&value); err != nil {
+			return fmt.Errorf("failed to query read_only: %w", err)
+		}
+		readOnly = value != 0
+	default:
+		return nil
+	}
+
+	if readOnly {
+		return ErrReadOnly
+	}
+	return nil
+}
+
+// mongoCommandNotFound is the code of the CommandNotFound error MongoDB returns for unknown commands.
+const mongoCommandNotFound = 59
+
+// verifyMongoWritable returns an error wrapping ErrReadOnly if client is not connected to a writable primary.
+func verifyMongoWritable(ctx context.Context, client *mongo.Client) error {
+	return checkMongoWritable(func(command string) *mongo.SingleResult {
+		return client.Database("admin").RunCommand(ctx, bson.D{{Key: command, Value: 1}})
+	})
+}
+
+// checkMongoWritable checks the reply of the hello command run by run. Servers before MongoDB 4.4.2 do not
+// know hello and fail with CommandNotFound, so they are asked with the legacy isMaster command instead.
+func checkMongoWritable(run func(command string) *mongo.SingleResult) error {
+	var hello struct {
+		IsWritablePrimary bool `bson:"isWritablePrimary"`
+		// IsMaster is reported instead of isWritablePrimary by the isMaster command.
+		IsMaster bool `bson:"ismaster"`
+	}
+
+	command := "hello"
+	err := run(command).Decode(&hello)
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == mongoCommandNotFound {
+		command = "isMaster"
+		err = run(command).Decode(&hello)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to run %s: %w", command, err)
+	}
+
+	if !hello.IsWritablePrimary && !hello.IsMaster {
+		return fmt.Errorf("%w: not connected to the primary", ErrReadOnly)
+	}
+	return nil
+}